@@ -0,0 +1,156 @@
+// Command tag-service runs the Tag Service's gRPC/REST/WebSocket server, and
+// provides a handful of operational subcommands around it.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra" // v1.7.0
+	"go.mongodb.org/mongo-driver/mongo"         // v1.11.0
+	"go.mongodb.org/mongo-driver/mongo/options" // v1.11.0
+
+	"internal/bootstrap"
+	"internal/config"
+	"internal/logging"
+	"internal/repository"
+)
+
+// version is overridden at build time via -ldflags "-X main.version=...".
+var version = "dev"
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "tag-service",
+		Short:         "Spatial Tag Service",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	root.PersistentFlags().String("config", "", "path to a YAML or TOML config file")
+	root.PersistentFlags().String("grpc-host", "", "override grpc.host")
+	root.PersistentFlags().Int("grpc-port", 0, "override grpc.port")
+	root.PersistentFlags().String("log-level", "", "override log.level")
+
+	root.AddCommand(newServeCmd())
+	root.AddCommand(newMigrateIndexesCmd())
+	root.AddCommand(newValidateConfigCmd())
+	root.AddCommand(newVersionCmd())
+
+	return root
+}
+
+// newServeCmd loads config, builds a logger, and runs bootstrap.Run alongside
+// cfg.Watch so a running process picks up hot-reloadable config changes.
+func newServeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run the gRPC/REST/WebSocket server",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger, levelVar, err := newLogger(cfg)
+			if err != nil {
+				return err
+			}
+			cfg.SetLevelVar(levelVar)
+
+			watchCtx, cancelWatch := context.WithCancel(cmd.Context())
+			defer cancelWatch()
+			go func() {
+				if err := cfg.Watch(watchCtx); err != nil {
+					logger.Warn("config watch stopped", "operation", "serve", "error", err)
+				}
+			}()
+
+			return bootstrap.Run(cfg, logger)
+		},
+	}
+}
+
+// newMigrateIndexesCmd connects to MongoDB and exits once
+// repository.NewMongoRepository has ensured the tag collection's indexes,
+// for use as a pre-deploy migration step ahead of rolling out new replicas.
+func newMigrateIndexesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate-indexes",
+		Short: "Connect to MongoDB and ensure the tag collection's indexes exist",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger, _, err := newLogger(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), cfg.Mongo.Timeout)
+			defer cancel()
+
+			client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.Mongo.URI))
+			if err != nil {
+				return fmt.Errorf("failed to connect to mongodb: %w", err)
+			}
+			defer client.Disconnect(context.Background())
+
+			if _, err := repository.NewMongoRepository(client, cfg, logger); err != nil {
+				return fmt.Errorf("failed to ensure indexes: %w", err)
+			}
+
+			logger.Info("indexes ensured", "operation", "migrate_indexes")
+			return nil
+		},
+	}
+}
+
+// newValidateConfigCmd loads and validates configuration without starting
+// anything, for use as a CI or pre-deploy sanity check.
+func newValidateConfigCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate-config",
+		Short: "Load and validate configuration without starting the server",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := config.Load(cmd); err != nil {
+				return err
+			}
+			fmt.Println("configuration is valid")
+			return nil
+		},
+	}
+}
+
+func newVersionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the tag-service version",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println(version)
+			return nil
+		},
+	}
+}
+
+func newLogger(cfg *config.Config) (*slog.Logger, *slog.LevelVar, error) {
+	return logging.NewLogger(logging.LoggingConfig{
+		Level:      cfg.Logging.Level,
+		Format:     cfg.Logging.Format,
+		Output:     cfg.Logging.Output,
+		FilePath:   cfg.Logging.FilePath,
+		SampleRate: cfg.Logging.SampleRate,
+		RedactKeys: cfg.Logging.RedactKeys,
+	})
+}
@@ -0,0 +1,163 @@
+// Package bootstrap wires the gRPC server, MongoDB connection, and
+// background maintenance into a concurrent actor group, so a slow or
+// unreachable MongoDB never delays the gRPC listener from coming up and
+// failing a k8s liveness probe.
+package bootstrap
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+    "os"
+    "os/signal"
+    "syscall"
+    "time"
+
+    "github.com/go-redis/redis/v8" // v8.11.5
+    "github.com/oklog/run"         // v1.1.0
+    "go.mongodb.org/mongo-driver/mongo"         // v1.11.0
+    "go.mongodb.org/mongo-driver/mongo/options" // v1.11.0
+
+    "internal/config"
+    "internal/repository"
+    "internal/server"
+    "internal/service"
+)
+
+// Run builds the gRPC/gateway server and starts it alongside a MongoDB
+// connect actor, an expired-tag cleanup ticker, the health reporter's probe
+// loop, and OS signal handling. It blocks until every actor has exited,
+// returning the first terminal error (nil on a clean shutdown signal). Any
+// one actor's terminal error cancels the others via their interrupt funcs.
+func Run(cfg *config.Config, logger *slog.Logger) error {
+    srv, err := server.NewServer(cfg, logger)
+    if err != nil {
+        return fmt.Errorf("failed to create server: %w", err)
+    }
+
+    var g run.Group
+
+    addServerActor(&g, srv)
+    addMongoConnectActor(&g, srv, cfg, logger)
+    addCleanupActor(&g, srv, cfg, logger)
+    addHealthReporterActor(&g, srv)
+    addSignalActor(&g, logger)
+
+    return g.Run()
+}
+
+// addServerActor runs the gRPC listener, gateway, and their graceful
+// shutdown. It comes up immediately; RPC handlers report Unavailable until
+// the Mongo connect actor calls srv.MarkReady.
+func addServerActor(g *run.Group, srv *server.Server) {
+    ctx, cancel := context.WithCancel(context.Background())
+    g.Add(func() error {
+        return srv.Start(ctx)
+    }, func(error) {
+        cancel()
+    })
+}
+
+// addMongoConnectActor connects to MongoDB, ensures indexes (via
+// repository.NewMongoRepository), builds the Redis-backed TagService, and
+// calls srv.MarkReady - the point at which the gRPC handlers stop returning
+// Unavailable. It then blocks until interrupted, disconnecting the client on
+// the way out.
+func addMongoConnectActor(g *run.Group, srv *server.Server, cfg *config.Config, logger *slog.Logger) {
+    ctx, cancel := context.WithCancel(context.Background())
+    g.Add(func() error {
+        connectCtx, connectCancel := context.WithTimeout(ctx, cfg.Mongo.Timeout)
+        defer connectCancel()
+
+        client, err := mongo.Connect(connectCtx, options.Client().ApplyURI(cfg.Mongo.URI))
+        if err != nil {
+            return fmt.Errorf("failed to connect to mongodb: %w", err)
+        }
+
+        repo, err := repository.NewMongoRepository(client, cfg, logger)
+        if err != nil {
+            return fmt.Errorf("failed to create repository: %w", err)
+        }
+
+        cache := redis.NewClient(&redis.Options{
+            Addr:     cfg.Redis.Addr,
+            Password: cfg.Redis.Password,
+            DB:       cfg.Redis.DB,
+        })
+
+        svc, err := service.NewTagService(repo, cache, logger)
+        if err != nil {
+            return fmt.Errorf("failed to create tag service: %w", err)
+        }
+
+        srv.MarkReady(svc, repo)
+        logger.Info("tag service ready", "operation", "bootstrap")
+
+        <-ctx.Done()
+        return client.Disconnect(context.Background())
+    }, func(error) {
+        cancel()
+    })
+}
+
+// addCleanupActor waits for srv.Ready before running CleanupExpiredTags on
+// cfg.Tag.CleanupInterval, since it needs the repository MarkReady supplies.
+func addCleanupActor(g *run.Group, srv *server.Server, cfg *config.Config, logger *slog.Logger) {
+    ctx, cancel := context.WithCancel(context.Background())
+    g.Add(func() error {
+        select {
+        case <-ctx.Done():
+            return nil
+        case <-srv.Ready():
+        }
+
+        ticker := time.NewTicker(cfg.Tag.CleanupInterval)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ctx.Done():
+                return nil
+            case <-ticker.C:
+                if err := srv.Repository().CleanupExpiredTags(context.Background()); err != nil {
+                    logger.Error("expired tag cleanup failed", "operation", "cleanup_expired_tags", "error", err)
+                }
+            }
+        }
+    }, func(error) {
+        cancel()
+    })
+}
+
+// addHealthReporterActor runs the health.Reporter's probe loop as its own
+// actor, so a probe hanging against a wedged dependency can't block server
+// startup or shutdown.
+func addHealthReporterActor(g *run.Group, srv *server.Server) {
+    ctx, cancel := context.WithCancel(context.Background())
+    g.Add(func() error {
+        srv.HealthReporter().Start(ctx)
+        return nil
+    }, func(error) {
+        cancel()
+        srv.HealthReporter().Stop()
+    })
+}
+
+// addSignalActor exits cleanly on SIGINT/SIGTERM, unwinding every other actor.
+func addSignalActor(g *run.Group, logger *slog.Logger) {
+    ctx, cancel := context.WithCancel(context.Background())
+    g.Add(func() error {
+        sigCh := make(chan os.Signal, 1)
+        signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+        defer signal.Stop(sigCh)
+
+        select {
+        case sig := <-sigCh:
+            logger.Info("received shutdown signal", "operation", "bootstrap", "signal", sig.String())
+            return nil
+        case <-ctx.Done():
+            return nil
+        }
+    }, func(error) {
+        cancel()
+    })
+}
@@ -5,9 +5,13 @@ package config
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/caarlos0/env/v6" // v6.10.0
+	"github.com/spf13/viper"     // v1.15.0
 )
 
 // Default configuration values
@@ -28,53 +32,122 @@ const (
 	EnvProduction  = "production"
 )
 
-// Config represents the main configuration structure for the Tag Service
+// Config represents the main configuration structure for the Tag Service.
+// mapstructure tags give viper's file/flag/env decoding the same key names
+// LoadConfig's caarlos0/env tags already established, so TAG_SERVICE_MONGO_URI
+// and a config file's mongo.uri address the same field (see viper.go's Load).
 type Config struct {
-	Environment string `env:"ENV" envDefault:"development"`
-	Version     string `env:"VERSION" envDefault:"1.0.0"`
-	Mongo       MongoConfig
-	GRPC        GRPCConfig
-	Tag         TagConfig
-	Security    SecurityConfig
+	Environment string `env:"ENV" envDefault:"development" mapstructure:"env"`
+	Version     string `env:"VERSION" envDefault:"1.0.0" mapstructure:"version"`
+	Mongo       MongoConfig    `mapstructure:"mongo"`
+	Redis       RedisConfig    `mapstructure:"redis"`
+	GRPC        GRPCConfig     `mapstructure:"grpc"`
+	Tag         TagConfig      `mapstructure:"tag"`
+	Security    SecurityConfig `mapstructure:"security"`
+	OTLP        OTLPConfig     `mapstructure:"otlp"`
+	Logging     LoggingConfig  `mapstructure:"log"`
+	HTTP        HTTPConfig     `mapstructure:"http"`
+	Health      HealthConfig   `mapstructure:"health"`
+
+	// mu guards the hot-reloadable fields Config.Watch swaps in; v is the
+	// viper instance Load built this Config from, used by Watch to observe
+	// the config file. levelVar, if set via SetLevelVar, is the live logger
+	// level Watch updates in place so a log.level reload actually changes
+	// what the running logger emits. v and levelVar are nil for a Config
+	// built via LoadConfig.
+	mu       sync.RWMutex
+	v        *viper.Viper
+	levelVar *slog.LevelVar
 }
 
 // MongoConfig holds MongoDB-specific configuration
 type MongoConfig struct {
-	URI        string        `env:"MONGO_URI,required"`
-	Database   string        `env:"MONGO_DB,required"`
-	Collection string        `env:"MONGO_COLLECTION,required"`
-	Timeout    time.Duration `env:"MONGO_TIMEOUT" envDefault:"10s"`
-	MaxPoolSize int          `env:"MONGO_MAX_POOL_SIZE" envDefault:"100"`
-	EnableSSL  bool          `env:"MONGO_ENABLE_SSL" envDefault:"true"`
-	ReplicaSet string        `env:"MONGO_REPLICA_SET"`
+	URI        string        `env:"MONGO_URI,required" mapstructure:"uri"`
+	Database   string        `env:"MONGO_DB,required" mapstructure:"db"`
+	Collection string        `env:"MONGO_COLLECTION,required" mapstructure:"collection"`
+	Timeout    time.Duration `env:"MONGO_TIMEOUT" envDefault:"10s" mapstructure:"timeout"`
+	MaxPoolSize int          `env:"MONGO_MAX_POOL_SIZE" envDefault:"100" mapstructure:"max_pool_size"`
+	EnableSSL  bool          `env:"MONGO_ENABLE_SSL" envDefault:"true" mapstructure:"enable_ssl"`
+	ReplicaSet string        `env:"MONGO_REPLICA_SET" mapstructure:"replica_set"`
+}
+
+// RedisConfig holds the cache/pubsub client configuration used by
+// TagService's caching and Subscribe.
+type RedisConfig struct {
+	Addr     string `env:"REDIS_ADDR" envDefault:"localhost:6379" mapstructure:"addr"`
+	Password string `env:"REDIS_PASSWORD" mapstructure:"password"`
+	DB       int    `env:"REDIS_DB" envDefault:"0" mapstructure:"db"`
 }
 
 // GRPCConfig holds gRPC server configuration
 type GRPCConfig struct {
-	Host      string        `env:"GRPC_HOST" envDefault:"0.0.0.0"`
-	Port      int          `env:"GRPC_PORT" envDefault:"50051"`
-	Timeout   time.Duration `env:"GRPC_TIMEOUT" envDefault:"30s"`
-	EnableTLS bool         `env:"GRPC_ENABLE_TLS" envDefault:"true"`
-	CertFile  string        `env:"GRPC_CERT_FILE"`
-	KeyFile   string        `env:"GRPC_KEY_FILE"`
+	Host      string        `env:"GRPC_HOST" envDefault:"0.0.0.0" mapstructure:"host"`
+	Port      int          `env:"GRPC_PORT" envDefault:"50051" mapstructure:"port"`
+	Timeout   time.Duration `env:"GRPC_TIMEOUT" envDefault:"30s" mapstructure:"timeout"`
+	EnableTLS bool         `env:"GRPC_ENABLE_TLS" envDefault:"true" mapstructure:"enable_tls"`
+	CertFile  string        `env:"GRPC_CERT_FILE" mapstructure:"cert_file"`
+	KeyFile   string        `env:"GRPC_KEY_FILE" mapstructure:"key_file"`
+}
+
+// HTTPConfig holds the grpc-gateway/WebSocket bridge configuration.
+type HTTPConfig struct {
+	Host                     string   `env:"HTTP_HOST" envDefault:"0.0.0.0" mapstructure:"host"`
+	Port                     int      `env:"HTTP_PORT" envDefault:"8080" mapstructure:"port"`
+	CORSOrigins              []string `env:"HTTP_CORS_ORIGINS" envSeparator:"," mapstructure:"cors_origins"`
+	// WebsocketMaxMessageBytes overrides grpc-websocket-proxy's 64 KiB
+	// default, which is too small for a GetNearbyTags-sized StreamTagUpdates
+	// payload in a dense area.
+	WebsocketMaxMessageBytes int `env:"WEBSOCKET_MAX_MESSAGE_BYTES" envDefault:"1048576" mapstructure:"websocket_max_message_bytes"`
 }
 
-// TagConfig holds tag-specific service configuration
+// TagConfig holds tag-specific service configuration. CleanupInterval,
+// DefaultExpiration and MaxTagsPerUser are hot-reloadable - see Config.Watch.
 type TagConfig struct {
-	DefaultVisibilityRadius float64       `env:"TAG_DEFAULT_VISIBILITY_RADIUS" envDefault:"50.0"`
-	DefaultExpiration      time.Duration `env:"TAG_DEFAULT_EXPIRATION" envDefault:"24h"`
-	CleanupInterval       time.Duration `env:"TAG_CLEANUP_INTERVAL" envDefault:"1h"`
-	MaxTagsPerUser        int          `env:"TAG_MAX_PER_USER" envDefault:"100"`
-	MaxTagSize           int          `env:"TAG_MAX_SIZE" envDefault:"1048576"` // 1MB
-	EnableContentValidation bool       `env:"TAG_ENABLE_CONTENT_VALIDATION" envDefault:"true"`
+	DefaultVisibilityRadius float64       `env:"TAG_DEFAULT_VISIBILITY_RADIUS" envDefault:"50.0" mapstructure:"default_visibility_radius"`
+	DefaultExpiration      time.Duration `env:"TAG_DEFAULT_EXPIRATION" envDefault:"24h" mapstructure:"default_expiration"`
+	CleanupInterval       time.Duration `env:"TAG_CLEANUP_INTERVAL" envDefault:"1h" mapstructure:"cleanup_interval"`
+	MaxTagsPerUser        int          `env:"TAG_MAX_PER_USER" envDefault:"100" mapstructure:"max_per_user"`
+	MaxTagSize           int          `env:"TAG_MAX_SIZE" envDefault:"1048576" mapstructure:"max_size"` // 1MB
+	EnableContentValidation bool       `env:"TAG_ENABLE_CONTENT_VALIDATION" envDefault:"true" mapstructure:"enable_content_validation"`
+	IndexResolution       int          `env:"TAG_INDEX_RESOLUTION" envDefault:"8" mapstructure:"index_resolution"` // H3 resolution used for the $in candidate lookup
+}
+
+// OTLPConfig holds OpenTelemetry exporter configuration. server.NewServer
+// builds the global TracerProvider from it (see initTracerProvider and
+// otel.SetTracerProvider there); no MeterProvider is built from it.
+// TagService/MongoRepository take their tracers from the global provider via
+// otel.Tracer(...).
+type OTLPConfig struct {
+	Endpoint    string  `env:"OTLP_ENDPOINT" envDefault:"localhost:4317" mapstructure:"endpoint"`
+	ServiceName string  `env:"OTLP_SERVICE_NAME" envDefault:"tag-service" mapstructure:"service_name"`
+	Insecure    bool    `env:"OTLP_INSECURE" envDefault:"true" mapstructure:"insecure"`
+	SampleRatio float64 `env:"OTLP_SAMPLE_RATIO" envDefault:"1.0" mapstructure:"sample_ratio"`
+}
+
+// LoggingConfig holds structured-logging configuration. Callers translate
+// this into logging.LoggingConfig when building a logger (see
+// internal/logging.NewLogger) rather than this package depending on it.
+// Level is hot-reloadable - see Config.Watch.
+type LoggingConfig struct {
+	Level      string   `env:"LOG_LEVEL" envDefault:"info" mapstructure:"level"`
+	Format     string   `env:"LOG_FORMAT" envDefault:"json" mapstructure:"format"`
+	Output     string   `env:"LOG_OUTPUT" envDefault:"stdout" mapstructure:"output"`
+	FilePath   string   `env:"LOG_FILE_PATH" mapstructure:"file_path"`
+	SampleRate float64  `env:"LOG_SAMPLE_RATE" envDefault:"1.0" mapstructure:"sample_rate"`
+	RedactKeys []string `env:"LOG_REDACT_KEYS" envSeparator:"," mapstructure:"redact_keys"`
+}
+
+// HealthConfig holds health.Reporter's dependency-probe configuration.
+type HealthConfig struct {
+	ProbeInterval time.Duration `env:"HEALTH_PROBE_INTERVAL" envDefault:"10s" mapstructure:"probe_interval"`
 }
 
 // SecurityConfig holds security-specific configuration
 type SecurityConfig struct {
-	EnableAuditLog    bool          `env:"SECURITY_ENABLE_AUDIT_LOG" envDefault:"true"`
-	EncryptionKey     string        `env:"SECURITY_ENCRYPTION_KEY,required"`
-	TokenExpiration   time.Duration `env:"SECURITY_TOKEN_EXPIRATION" envDefault:"1h"`
-	MaxFailedAttempts int          `env:"SECURITY_MAX_FAILED_ATTEMPTS" envDefault:"5"`
+	EnableAuditLog    bool          `env:"SECURITY_ENABLE_AUDIT_LOG" envDefault:"true" mapstructure:"enable_audit_log"`
+	EncryptionKey     string        `env:"SECURITY_ENCRYPTION_KEY,required" mapstructure:"encryption_key"`
+	TokenExpiration   time.Duration `env:"SECURITY_TOKEN_EXPIRATION" envDefault:"1h" mapstructure:"token_expiration"`
+	MaxFailedAttempts int          `env:"SECURITY_MAX_FAILED_ATTEMPTS" envDefault:"5" mapstructure:"max_failed_attempts"`
 }
 
 // LoadConfig loads and validates configuration from environment variables
@@ -84,7 +157,10 @@ func LoadConfig() (*Config, error) {
 	opts := env.Options{
 		Prefix: "TAG_SERVICE_",
 		OnSet: func(tag string, value interface{}, isDefault bool) {
-			// Log configuration loading for audit purposes
+			if strings.Contains(tag, "KEY") || strings.Contains(tag, "SECRET") {
+				value = "[REDACTED]"
+			}
+			slog.Debug("config value set", "field", tag, "value", value, "default", isDefault)
 		},
 	}
 
@@ -123,11 +199,36 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("tag config validation failed: %w", err)
 	}
 
+	// Validate redis configuration
+	if err := c.validateRedisConfig(); err != nil {
+		return fmt.Errorf("redis config validation failed: %w", err)
+	}
+
 	// Validate security configuration
 	if err := c.validateSecurityConfig(); err != nil {
 		return fmt.Errorf("security config validation failed: %w", err)
 	}
 
+	// Validate OTLP configuration
+	if err := c.validateOTLPConfig(); err != nil {
+		return fmt.Errorf("otlp config validation failed: %w", err)
+	}
+
+	// Validate logging configuration
+	if err := c.validateLoggingConfig(); err != nil {
+		return fmt.Errorf("logging config validation failed: %w", err)
+	}
+
+	// Validate HTTP gateway configuration
+	if err := c.validateHTTPConfig(); err != nil {
+		return fmt.Errorf("http config validation failed: %w", err)
+	}
+
+	// Validate health probe configuration
+	if err := c.validateHealthConfig(); err != nil {
+		return fmt.Errorf("health config validation failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -198,6 +299,9 @@ func (c *Config) validateTagConfig() error {
 	if c.Tag.MaxTagSize < 1 {
 		return errors.New("max tag size must be positive")
 	}
+	if c.Tag.IndexResolution < 8 || c.Tag.IndexResolution > 12 {
+		return errors.New("index resolution must be between 8 and 12")
+	}
 
 	return nil
 }
@@ -223,5 +327,81 @@ func (c *Config) validateSecurityConfig() error {
 		}
 	}
 
+	return nil
+}
+
+func (c *Config) validateOTLPConfig() error {
+	if c.OTLP.Endpoint == "" {
+		return errors.New("otlp endpoint is required")
+	}
+	if c.OTLP.ServiceName == "" {
+		return errors.New("otlp service name is required")
+	}
+	if c.OTLP.SampleRatio < 0 || c.OTLP.SampleRatio > 1 {
+		return errors.New("otlp sample ratio must be between 0 and 1")
+	}
+
+	// Production-specific validations
+	if c.Environment == EnvProduction && c.OTLP.Insecure {
+		return errors.New("otlp exporter must use a secure connection in production")
+	}
+
+	return nil
+}
+
+func (c *Config) validateLoggingConfig() error {
+	switch c.Logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		return errors.New("invalid logging level specified")
+	}
+
+	switch c.Logging.Format {
+	case "json", "text":
+	default:
+		return errors.New("invalid logging format specified")
+	}
+
+	switch c.Logging.Output {
+	case "stdout":
+	case "file":
+		if c.Logging.FilePath == "" {
+			return errors.New("logging file path is required when output is \"file\"")
+		}
+	default:
+		return errors.New("invalid logging output specified")
+	}
+
+	if c.Logging.SampleRate <= 0 || c.Logging.SampleRate > 1 {
+		return errors.New("logging sample rate must be between 0 (exclusive) and 1")
+	}
+
+	return nil
+}
+
+func (c *Config) validateHTTPConfig() error {
+	if c.HTTP.Port < 1024 || c.HTTP.Port > 65535 {
+		return errors.New("invalid HTTP port number")
+	}
+	if c.HTTP.WebsocketMaxMessageBytes < 65536 {
+		return errors.New("websocket max message bytes must be at least 65536 (the grpc-websocket-proxy default)")
+	}
+
+	return nil
+}
+
+func (c *Config) validateRedisConfig() error {
+	if c.Redis.Addr == "" {
+		return errors.New("redis address is required")
+	}
+
+	return nil
+}
+
+func (c *Config) validateHealthConfig() error {
+	if c.Health.ProbeInterval < time.Second {
+		return errors.New("health probe interval must be at least 1 second")
+	}
+
 	return nil
 }
\ No newline at end of file
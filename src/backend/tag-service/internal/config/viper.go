@@ -0,0 +1,268 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"              // v1.6.0
+	"github.com/prometheus/client_golang/prometheus" // v1.11.0
+	"github.com/spf13/cobra"                    // v1.7.0
+	"github.com/spf13/viper"                    // v1.15.0
+)
+
+// envPrefix matches LoadConfig's "TAG_SERVICE_" prefix, minus the trailing
+// underscore viper's SetEnvPrefix adds back itself.
+const envPrefix = "TAG_SERVICE"
+
+var configReloadsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "tag_service_config_reloads_total",
+		Help: "Total number of config hot-reload attempts, partitioned by result",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(configReloadsTotal)
+}
+
+// Load builds a Config from, in increasing precedence: built-in defaults, an
+// optional --config file (YAML or TOML), TAG_SERVICE_-prefixed environment
+// variables, and cmd's flags. It supersedes LoadConfig for entrypoints that
+// want file and flag support; LoadConfig remains for callers that only need
+// the environment-variable path.
+func Load(cmd *cobra.Command) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	// The key replacer would map this key to TAG_SERVICE_HTTP_WEBSOCKET_...;
+	// bind it to the env name LoadConfig reads instead.
+	if err := v.BindEnv("http.websocket_max_message_bytes", envPrefix+"_WEBSOCKET_MAX_MESSAGE_BYTES"); err != nil {
+		return nil, fmt.Errorf("failed to bind env: %w", err)
+	}
+
+	if cmd != nil {
+		if err := bindFlags(v, cmd); err != nil {
+			return nil, fmt.Errorf("failed to bind flags: %w", err)
+		}
+		if configPath, _ := cmd.Flags().GetString("config"); configPath != "" {
+			v.SetConfigFile(configPath)
+			if err := v.ReadInConfig(); err != nil {
+				return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+			}
+		}
+	}
+
+	cfg := &Config{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+
+	cfg.v = v
+	return cfg, nil
+}
+
+// flagKeys maps a cobra flag name (see newRootCmd) to the nested
+// mapstructure key it overrides. Binding via v.BindPFlags(cmd.Flags())
+// alone would register each flag under its own unnested name, which never
+// matches a dotted Config key, silently dropping the "flags > env > file >
+// defaults" precedence Load promises for these flags.
+var flagKeys = map[string]string{
+	"grpc-host": "grpc.host",
+	"grpc-port": "grpc.port",
+	"log-level": "log.level",
+}
+
+// bindFlags binds every flag named in flagKeys to its nested viper key, so
+// overriding GRPC.Host, GRPC.Port, or Logging.Level works from the CLI.
+func bindFlags(v *viper.Viper, cmd *cobra.Command) error {
+	for flagName, key := range flagKeys {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil {
+			continue
+		}
+		if err := v.BindPFlag(key, flag); err != nil {
+			return fmt.Errorf("failed to bind flag %q to %q: %w", flagName, key, err)
+		}
+	}
+	return nil
+}
+
+// setDefaults mirrors the envDefault values on Config's struct tags, so a
+// field left unset by file, env, and flags still resolves the same way
+// LoadConfig's caarlos0/env defaults would.
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("env", EnvDevelopment)
+	v.SetDefault("version", "1.0.0")
+
+	v.SetDefault("mongo.timeout", DefaultMongoTimeout)
+	v.SetDefault("mongo.max_pool_size", DefaultMaxPoolSize)
+	v.SetDefault("mongo.enable_ssl", true)
+
+	v.SetDefault("redis.addr", "localhost:6379")
+	v.SetDefault("redis.db", 0)
+
+	v.SetDefault("grpc.host", "0.0.0.0")
+	v.SetDefault("grpc.port", DefaultGRPCPort)
+	v.SetDefault("grpc.timeout", 30*time.Second)
+	v.SetDefault("grpc.enable_tls", true)
+
+	v.SetDefault("http.host", "0.0.0.0")
+	v.SetDefault("http.port", 8080)
+	v.SetDefault("http.websocket_max_message_bytes", 1048576)
+
+	v.SetDefault("tag.default_visibility_radius", DefaultVisibilityRadius)
+	v.SetDefault("tag.default_expiration", DefaultTagExpiration)
+	v.SetDefault("tag.cleanup_interval", DefaultCleanupInterval)
+	v.SetDefault("tag.max_per_user", DefaultMaxTagsPerUser)
+	v.SetDefault("tag.max_size", 1048576)
+	v.SetDefault("tag.enable_content_validation", true)
+	v.SetDefault("tag.index_resolution", 8)
+
+	v.SetDefault("otlp.endpoint", "localhost:4317")
+	v.SetDefault("otlp.service_name", "tag-service")
+	v.SetDefault("otlp.insecure", true)
+	v.SetDefault("otlp.sample_ratio", 1.0)
+
+	v.SetDefault("log.level", "info")
+	v.SetDefault("log.format", "json")
+	v.SetDefault("log.output", "stdout")
+	v.SetDefault("log.sample_rate", 1.0)
+
+	v.SetDefault("health.probe_interval", 10*time.Second)
+
+	v.SetDefault("security.enable_audit_log", true)
+	v.SetDefault("security.token_expiration", time.Hour)
+	v.SetDefault("security.max_failed_attempts", 5)
+
+	// Keys with no envDefault still need registering, since AutomaticEnv
+	// only supplies a key to Unmarshal once viper knows the key exists.
+	for _, key := range []string{"mongo.uri", "mongo.db", "mongo.collection", "mongo.replica_set",
+		"redis.password", "grpc.cert_file", "grpc.key_file", "log.file_path", "security.encryption_key"} {
+		v.SetDefault(key, "")
+	}
+	v.SetDefault("http.cors_origins", []string{})
+	v.SetDefault("log.redact_keys", []string{})
+}
+
+// SetLevelVar wires lv as the live level control a hot reload adjusts; pass
+// the *slog.LevelVar returned by logging.NewLogger's handler so changing
+// log.level in the watched config file actually changes what the running
+// logger emits, instead of only updating c.Logging.Level. Leaving this
+// unset means Watch still accepts a log.level change but the logger's
+// level stays fixed at whatever it was built with.
+func (c *Config) SetLevelVar(lv *slog.LevelVar) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.levelVar = lv
+}
+
+// levelFromString mirrors logging.ParseLevel's string-to-slog.Level mapping.
+// Duplicated rather than imported so this package doesn't depend on
+// internal/logging (see LoggingConfig's doc comment in config.go).
+func levelFromString(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// immutableFieldError reports a hot-reload attempt that would have changed a
+// field Watch treats as fixed for the life of the process.
+type immutableFieldError struct {
+	field string
+}
+
+func (e *immutableFieldError) Error() string {
+	return fmt.Sprintf("config field %q cannot be changed by a hot reload, restart the process instead", e.field)
+}
+
+// Watch blocks, re-reading c's backing config file on every change (via
+// viper's fsnotify integration) and atomically swapping the hot-reloadable
+// fields - Tag.CleanupInterval, Tag.DefaultExpiration, Tag.MaxTagsPerUser,
+// and Logging.Level - into c. A reload is re-validated with Validate before
+// being applied, and rejected outright if it would change Mongo.URI,
+// GRPC.Port, GRPC.CertFile, or GRPC.KeyFile; either outcome increments
+// tag_service_config_reloads_total. Returns when ctx is cancelled. Load must
+// have been given a --config file for there to be anything to watch.
+func (c *Config) Watch(ctx context.Context) error {
+	if c.v == nil {
+		return fmt.Errorf("config was not loaded from a file, nothing to watch")
+	}
+
+	c.v.OnConfigChange(func(e fsnotify.Event) {
+		if err := c.reload(); err != nil {
+			configReloadsTotal.WithLabelValues("rejected").Inc()
+			slog.Error("config reload rejected", "operation", "config_watch", "file", e.Name, "error", err)
+			return
+		}
+		configReloadsTotal.WithLabelValues("applied").Inc()
+		slog.Info("config reload applied", "operation", "config_watch", "file", e.Name)
+	})
+	c.v.WatchConfig()
+
+	<-ctx.Done()
+	return nil
+}
+
+// reload decodes c's backing viper instance into a fresh Config, validates
+// it, rejects it if any immutable field changed, and otherwise swaps the
+// hot-reloadable fields into c under c.mu.
+func (c *Config) reload() error {
+	next := &Config{}
+	if err := c.v.Unmarshal(next); err != nil {
+		return fmt.Errorf("failed to decode reloaded config: %w", err)
+	}
+	if err := next.Validate(); err != nil {
+		return fmt.Errorf("reloaded config failed validation: %w", err)
+	}
+
+	c.mu.RLock()
+	immutable := checkImmutable(c, next)
+	c.mu.RUnlock()
+	if immutable != nil {
+		return immutable
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Tag.CleanupInterval = next.Tag.CleanupInterval
+	c.Tag.DefaultExpiration = next.Tag.DefaultExpiration
+	c.Tag.MaxTagsPerUser = next.Tag.MaxTagsPerUser
+	c.Logging.Level = next.Logging.Level
+	if c.levelVar != nil {
+		c.levelVar.Set(levelFromString(next.Logging.Level))
+	}
+	return nil
+}
+
+func checkImmutable(current, next *Config) error {
+	switch {
+	case current.Mongo.URI != next.Mongo.URI:
+		return &immutableFieldError{field: "mongo.uri"}
+	case current.GRPC.Port != next.GRPC.Port:
+		return &immutableFieldError{field: "grpc.port"}
+	case current.GRPC.CertFile != next.GRPC.CertFile:
+		return &immutableFieldError{field: "grpc.cert_file"}
+	case current.GRPC.KeyFile != next.GRPC.KeyFile:
+		return &immutableFieldError{field: "grpc.key_file"}
+	default:
+		return nil
+	}
+}
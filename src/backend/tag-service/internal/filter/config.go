@@ -0,0 +1,141 @@
+package filter
+
+import (
+    "fmt"
+    "os"
+    "time"
+
+    "gopkg.in/yaml.v3" // v3.0.1
+)
+
+// ChainConfig is the YAML-configurable ordered list of filters a deployment
+// applies to GetNearbyTags results. Each entry names one built-in filter
+// kind and its parameters; entries are applied in order via AllOf semantics
+// unless combined explicitly with kind "any_of"/"all_of"/"not".
+type ChainConfig struct {
+    Filters []FilterConfig `yaml:"filters"`
+}
+
+// FilterConfig describes a single chain entry. Only the fields relevant to
+// Kind are read.
+type FilterConfig struct {
+    Kind    string         `yaml:"kind"`
+    Tiers   map[int]string `yaml:"tiers,omitempty"`
+    Allow   []string       `yaml:"allow,omitempty"`
+    Deny    []string       `yaml:"deny,omitempty"`
+    Polygon [][2]float64   `yaml:"polygon,omitempty"`
+    Open    int            `yaml:"open_hour,omitempty"`
+    Close   int            `yaml:"close_hour,omitempty"`
+    TZ      string         `yaml:"timezone,omitempty"`
+    Blocked []string       `yaml:"blocked,omitempty"`
+    Not     *FilterConfig  `yaml:"not,omitempty"`
+    // Filters holds the nested filters an "any_of"/"all_of" entry combines.
+    Filters []FilterConfig `yaml:"filters,omitempty"`
+}
+
+// LoadChain reads a YAML chain config from path and builds the ordered
+// []TagFilter TagService.WithFilters expects.
+func LoadChain(path string) ([]TagFilter, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read filter chain config: %w", err)
+    }
+
+    var cfg ChainConfig
+    if err := yaml.Unmarshal(data, &cfg); err != nil {
+        return nil, fmt.Errorf("failed to parse filter chain config: %w", err)
+    }
+
+    return BuildChain(cfg)
+}
+
+// BuildChain turns a parsed ChainConfig into the corresponding TagFilter
+// instances, in order.
+func BuildChain(cfg ChainConfig) ([]TagFilter, error) {
+    chain := make([]TagFilter, 0, len(cfg.Filters))
+    for _, entry := range cfg.Filters {
+        f, err := buildFilter(entry)
+        if err != nil {
+            return nil, err
+        }
+        chain = append(chain, f)
+    }
+    return chain, nil
+}
+
+func buildFilter(entry FilterConfig) (TagFilter, error) {
+    switch entry.Kind {
+    case "status_tier":
+        tiers := entry.Tiers
+        if tiers == nil {
+            return NewStatusTierFilter(), nil
+        }
+        return &StatusTierFilter{Tiers: tiers}, nil
+    case "category":
+        return &CategoryFilter{Allow: toSet(entry.Allow), Deny: toSet(entry.Deny)}, nil
+    case "geofence":
+        return &GeofenceFilter{Polygon: entry.Polygon}, nil
+    case "business_hours":
+        loc := time.UTC
+        if entry.TZ != "" {
+            parsed, err := time.LoadLocation(entry.TZ)
+            if err != nil {
+                return nil, fmt.Errorf("invalid timezone %q: %w", entry.TZ, err)
+            }
+            loc = parsed
+        }
+        return &BusinessHoursFilter{Location: loc, OpenHour: entry.Open, CloseHour: entry.Close}, nil
+    case "creator_blocklist":
+        return &CreatorBlocklistFilter{Blocked: toSet(entry.Blocked)}, nil
+    case "not":
+        if entry.Not == nil {
+            return nil, fmt.Errorf("\"not\" filter requires a nested filter")
+        }
+        inner, err := buildFilter(*entry.Not)
+        if err != nil {
+            return nil, err
+        }
+        return &NotFilter{Filter: inner}, nil
+    case "any_of":
+        inner, err := buildFilters(entry.Filters)
+        if err != nil {
+            return nil, err
+        }
+        return &AnyOf{Filters: inner}, nil
+    case "all_of":
+        inner, err := buildFilters(entry.Filters)
+        if err != nil {
+            return nil, err
+        }
+        return &AllOf{Filters: inner}, nil
+    default:
+        return nil, fmt.Errorf("unknown filter kind %q", entry.Kind)
+    }
+}
+
+// buildFilters builds each nested entry in order, for "any_of"/"all_of".
+func buildFilters(entries []FilterConfig) ([]TagFilter, error) {
+    if len(entries) == 0 {
+        return nil, fmt.Errorf("combinator filter requires at least one nested filter")
+    }
+    filters := make([]TagFilter, 0, len(entries))
+    for _, entry := range entries {
+        f, err := buildFilter(entry)
+        if err != nil {
+            return nil, err
+        }
+        filters = append(filters, f)
+    }
+    return filters, nil
+}
+
+func toSet(values []string) map[string]bool {
+    if len(values) == 0 {
+        return nil
+    }
+    set := make(map[string]bool, len(values))
+    for _, v := range values {
+        set[v] = true
+    }
+    return set
+}
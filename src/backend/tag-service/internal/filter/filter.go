@@ -0,0 +1,181 @@
+// Package filter implements the composable visibility/filter pipeline
+// TagService applies to GetNearbyTags results, replacing the single
+// hardcoded "userStatusLevel == elite" comparison that used to live in
+// models.Tag.IsVisible.
+package filter
+
+import (
+    "context"
+    "time"
+
+    "../models"
+)
+
+// Viewer describes the caller a candidate tag is being matched against.
+type Viewer struct {
+    UserID      string
+    StatusLevel string
+    Location    models.Location
+}
+
+// TagFilter decides whether a candidate tag should remain visible to viewer.
+// Chains are built from the primitives below and combined with AllOf/AnyOf/
+// Not, so an operator can express "elite-only OR creator is viewer" etc.
+// declaratively via YAML (see Config/BuildChain).
+type TagFilter interface {
+    // Name identifies the filter for the per-filter Prometheus counters
+    // TagService records (how many candidates each filter culled).
+    Name() string
+    Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool
+}
+
+// StatusTierFilter matches a tag when the viewer's StatusLevel equals the
+// level Tiers requires for the tag's Visibility, e.g. the pre-existing
+// EliteOnly/"elite" check. Levels are compared for equality only; they have
+// no ordering.
+type StatusTierFilter struct {
+    // Tiers maps a models.Tag Visibility level to the viewer StatusLevel
+    // required to see it. A level absent from Tiers requires no tier.
+    Tiers map[int]string
+}
+
+// NewStatusTierFilter builds the default tier mapping equivalent to the
+// original hardcoded check: TagVisibilityEliteOnly requires "elite".
+func NewStatusTierFilter() *StatusTierFilter {
+    return &StatusTierFilter{
+        Tiers: map[int]string{
+            models.TagVisibilityEliteOnly: "elite",
+        },
+    }
+}
+
+func (f *StatusTierFilter) Name() string { return "status_tier" }
+
+func (f *StatusTierFilter) Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool {
+    required, ok := f.Tiers[tag.Visibility]
+    if !ok {
+        return true
+    }
+    return viewer.StatusLevel == required
+}
+
+// CategoryFilter allows or denies tags by Tag.Category. Allow, if non-empty,
+// is checked first and is exclusive (deny is ignored when allow is set).
+type CategoryFilter struct {
+    Allow map[string]bool
+    Deny  map[string]bool
+}
+
+func (f *CategoryFilter) Name() string { return "category" }
+
+func (f *CategoryFilter) Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool {
+    if len(f.Allow) > 0 {
+        return f.Allow[tag.Category]
+    }
+    return !f.Deny[tag.Category]
+}
+
+// GeofenceFilter keeps only tags whose location falls inside Polygon, a
+// closed ring of (lat, lon) vertices evaluated with a ray-casting
+// point-in-polygon test.
+type GeofenceFilter struct {
+    Polygon [][2]float64
+}
+
+func (f *GeofenceFilter) Name() string { return "geofence" }
+
+func (f *GeofenceFilter) Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool {
+    if len(f.Polygon) < 3 {
+        return true
+    }
+    return pointInPolygon(tag.Location.Latitude, tag.Location.Longitude, f.Polygon)
+}
+
+func pointInPolygon(lat, lon float64, polygon [][2]float64) bool {
+    inside := false
+    j := len(polygon) - 1
+    for i := 0; i < len(polygon); i++ {
+        xi, yi := polygon[i][0], polygon[i][1]
+        xj, yj := polygon[j][0], polygon[j][1]
+        if (yi > lon) != (yj > lon) &&
+            lat < (xj-xi)*(lon-yi)/(yj-yi)+xi {
+            inside = !inside
+        }
+        j = i
+    }
+    return inside
+}
+
+// BusinessHoursFilter keeps tags only while the current time, evaluated in
+// the given location, falls within [OpenHour, CloseHour); outside that window
+// it drops every tag. A tag's own CreatedAt is not considered.
+type BusinessHoursFilter struct {
+    Location  *time.Location
+    OpenHour  int // 0-23
+    CloseHour int // 0-23
+}
+
+func (f *BusinessHoursFilter) Name() string { return "business_hours" }
+
+func (f *BusinessHoursFilter) Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool {
+    loc := f.Location
+    if loc == nil {
+        loc = time.UTC
+    }
+    hour := time.Now().In(loc).Hour()
+    return hour >= f.OpenHour && hour < f.CloseHour
+}
+
+// CreatorBlocklistFilter excludes tags authored by a blocked creator.
+type CreatorBlocklistFilter struct {
+    Blocked map[string]bool
+}
+
+func (f *CreatorBlocklistFilter) Name() string { return "creator_blocklist" }
+
+func (f *CreatorBlocklistFilter) Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool {
+    return !f.Blocked[tag.CreatorID]
+}
+
+// NotFilter inverts the wrapped filter's decision.
+type NotFilter struct {
+    Filter TagFilter
+}
+
+func (f *NotFilter) Name() string { return "not_" + f.Filter.Name() }
+
+func (f *NotFilter) Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool {
+    return !f.Filter.Match(ctx, tag, viewer)
+}
+
+// AllOf matches only if every wrapped filter matches (logical AND).
+type AllOf struct {
+    Filters []TagFilter
+}
+
+func (f *AllOf) Name() string { return "all_of" }
+
+func (f *AllOf) Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool {
+    for _, sub := range f.Filters {
+        if !sub.Match(ctx, tag, viewer) {
+            return false
+        }
+    }
+    return true
+}
+
+// AnyOf matches if at least one wrapped filter matches (logical OR).
+type AnyOf struct {
+    Filters []TagFilter
+}
+
+func (f *AnyOf) Name() string { return "any_of" }
+
+func (f *AnyOf) Match(ctx context.Context, tag *models.Tag, viewer *Viewer) bool {
+    for _, sub := range f.Filters {
+        if sub.Match(ctx, tag, viewer) {
+            return true
+        }
+    }
+    return false
+}
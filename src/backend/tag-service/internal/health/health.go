@@ -0,0 +1,275 @@
+// Package health maintains the serving status the gRPC health service reports,
+// driven by periodic dependency probes rather than a hardcoded SERVING value.
+package health
+
+import (
+    "context"
+    "log/slog"
+    "os"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus" // v1.11.0
+    "google.golang.org/grpc/health/grpc_health_v1"    // v1.45.0
+
+    "../logging"
+    "../repository"
+    "../service"
+)
+
+// TagServiceName is the fully-qualified gRPC service name status is reported
+// under for tag operations specifically, matching the tagservice.TagService
+// service defined in pkg/proto/tag_service.proto.
+const TagServiceName = "tagservice.TagService"
+
+// overallServiceName is the grpc_health_v1 convention for "the process as a
+// whole", the status returned when a Check/Watch request leaves Service empty.
+const overallServiceName = ""
+
+const (
+    mongoDependency = "mongodb"
+    cacheDependency = "redis"
+)
+
+var healthStatusGauge = prometheus.NewGaugeVec(
+    prometheus.GaugeOpts{
+        Name: "tag_service_health_status",
+        Help: "Current health status per dependency (1 = SERVING, 0 = NOT_SERVING)",
+    },
+    []string{"dependency"},
+)
+
+func init() {
+    prometheus.MustRegister(healthStatusGauge)
+}
+
+type subscriber chan grpc_health_v1.HealthCheckResponse_ServingStatus
+
+// Reporter probes MongoDB and Redis on a fixed interval and maintains the
+// per-service serving status grpc_health_v1.Check/Watch need. It replaces a
+// hardcoded SERVING response with one that actually reflects dependency state.
+//
+// repo and svc are supplied later via SetDependencies rather than at
+// construction, since the bootstrap actor group starts the reporter before
+// the MongoDB connect actor has necessarily finished; until SetDependencies
+// is called, probe reports every dependency SERVICE_UNKNOWN.
+type Reporter struct {
+    interval time.Duration
+    Logger   *slog.Logger
+
+    mu   sync.RWMutex
+    repo *repository.MongoRepository
+    svc  *service.TagService
+
+    status      map[string]grpc_health_v1.HealthCheckResponse_ServingStatus
+    subscribers map[string][]subscriber
+
+    stop chan struct{}
+    done chan struct{}
+}
+
+// NewReporter builds a Reporter that probes its dependencies every interval
+// once Start runs and SetDependencies has been called. If logger is nil, a
+// default JSON logger writing to stderr is used.
+func NewReporter(interval time.Duration, logger *slog.Logger) *Reporter {
+    if logger == nil {
+        logger = logging.NewJSONLogger(os.Stderr, slog.LevelInfo)
+    }
+
+    return &Reporter{
+        interval: interval,
+        Logger:   logger,
+        status: map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{
+            overallServiceName: grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN,
+            TagServiceName:     grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN,
+            mongoDependency:    grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN,
+            cacheDependency:    grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN,
+        },
+        subscribers: make(map[string][]subscriber),
+        stop:        make(chan struct{}),
+        done:        make(chan struct{}),
+    }
+}
+
+// SetDependencies supplies the repository and service probe calls are made
+// against, once the MongoDB connect actor has finished. Safe to call
+// concurrently with Start's probing loop.
+func (r *Reporter) SetDependencies(repo *repository.MongoRepository, svc *service.TagService) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.repo = repo
+    r.svc = svc
+}
+
+// Start probes dependencies immediately and then every interval, until ctx is
+// cancelled or Stop is called. It blocks, so callers run it in its own
+// goroutine. A repository handed over via SetDependencies has already
+// ensured its indexes synchronously during NewMongoRepository, so a probe
+// that uses it never races index creation.
+func (r *Reporter) Start(ctx context.Context) {
+    defer close(r.done)
+
+    r.probe(ctx)
+
+    ticker := time.NewTicker(r.interval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-r.stop:
+            return
+        case <-ticker.C:
+            r.probe(ctx)
+        }
+    }
+}
+
+// Stop halts probing, marks every known service NOT_SERVING, and closes every
+// active Watch subscriber channel after delivering that final status - so a
+// Watch handler blocked reading the channel returns promptly instead of
+// leaving GracefulStop waiting on a stream that will never end on its own.
+func (r *Reporter) Stop() {
+    close(r.stop)
+    <-r.done
+
+    r.mu.Lock()
+    subsByService := r.subscribers
+    r.subscribers = make(map[string][]subscriber)
+    for svc := range r.status {
+        r.status[svc] = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+    }
+    r.mu.Unlock()
+
+    for svc := range subsByService {
+        healthStatusGauge.WithLabelValues(svc).Set(0)
+    }
+    for _, subs := range subsByService {
+        for _, ch := range subs {
+            select {
+            case ch <- grpc_health_v1.HealthCheckResponse_NOT_SERVING:
+            default:
+            }
+            close(ch)
+        }
+    }
+}
+
+func (r *Reporter) probe(ctx context.Context) {
+    r.mu.RLock()
+    repo, svc := r.repo, r.svc
+    r.mu.RUnlock()
+
+    if repo == nil || svc == nil {
+        // MongoDB connect actor hasn't finished yet; nothing to probe.
+        r.setStatus(mongoDependency, grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN)
+        r.setStatus(cacheDependency, grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN)
+        r.setStatus(TagServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+        r.setStatus(overallServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+        return
+    }
+
+    mongoStatus := grpc_health_v1.HealthCheckResponse_SERVING
+    if err := repo.Ping(ctx); err != nil {
+        r.Logger.WarnContext(ctx, "dependency health probe failed",
+            "operation", "health_probe", "dependency", mongoDependency, "error", err)
+        mongoStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+    } else if ok, err := repo.HasRequiredIndexes(ctx); err != nil || !ok {
+        if err != nil {
+            r.Logger.WarnContext(ctx, "index presence probe failed",
+                "operation", "health_probe", "dependency", mongoDependency, "error", err)
+        } else {
+            r.Logger.WarnContext(ctx, "required index missing",
+                "operation", "health_probe", "dependency", mongoDependency)
+        }
+        mongoStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+    }
+
+    cacheStatus := grpc_health_v1.HealthCheckResponse_SERVING
+    if err := svc.PingCache(ctx); err != nil {
+        r.Logger.WarnContext(ctx, "dependency health probe failed",
+            "operation", "health_probe", "dependency", cacheDependency, "error", err)
+        cacheStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+    }
+
+    overall := grpc_health_v1.HealthCheckResponse_SERVING
+    if mongoStatus != grpc_health_v1.HealthCheckResponse_SERVING || cacheStatus != grpc_health_v1.HealthCheckResponse_SERVING {
+        overall = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+    }
+
+    r.setStatus(mongoDependency, mongoStatus)
+    r.setStatus(cacheDependency, cacheStatus)
+    r.setStatus(TagServiceName, overall)
+    r.setStatus(overallServiceName, overall)
+}
+
+func (r *Reporter) setStatus(service string, status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+    r.mu.Lock()
+    changed := r.status[service] != status
+    r.status[service] = status
+    subs := append([]subscriber(nil), r.subscribers[service]...)
+    r.mu.Unlock()
+
+    healthStatusGauge.WithLabelValues(service).Set(statusGaugeValue(status))
+
+    if !changed {
+        return
+    }
+    for _, sub := range subs {
+        select {
+        case sub <- status:
+        default:
+            // Slow Watch consumer; it will pick up the latest status on its
+            // next send rather than blocking every other subscriber here.
+        }
+    }
+}
+
+func statusGaugeValue(status grpc_health_v1.HealthCheckResponse_ServingStatus) float64 {
+    if status == grpc_health_v1.HealthCheckResponse_SERVING {
+        return 1
+    }
+    return 0
+}
+
+// Check returns the current status for service without blocking, or
+// SERVICE_UNKNOWN if service has never been probed.
+func (r *Reporter) Check(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    if status, ok := r.status[service]; ok {
+        return status
+    }
+    return grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+}
+
+// Watch registers a subscriber for service, returning a channel that receives
+// the current status immediately and every subsequent change. The caller
+// must invoke the returned cancel func when done, or the channel and its
+// slot in subscribers leak for the life of the Reporter.
+func (r *Reporter) Watch(service string) (<-chan grpc_health_v1.HealthCheckResponse_ServingStatus, func()) {
+    ch := make(subscriber, 1)
+
+    r.mu.Lock()
+    initial, ok := r.status[service]
+    if !ok {
+        initial = grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+    }
+    ch <- initial
+    r.subscribers[service] = append(r.subscribers[service], ch)
+    r.mu.Unlock()
+
+    cancel := func() {
+        r.mu.Lock()
+        defer r.mu.Unlock()
+        subs := r.subscribers[service]
+        for i, s := range subs {
+            if s == ch {
+                r.subscribers[service] = append(subs[:i], subs[i+1:]...)
+                close(ch)
+                break
+            }
+        }
+    }
+    return ch, cancel
+}
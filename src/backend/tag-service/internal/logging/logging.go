@@ -0,0 +1,324 @@
+// Package logging provides the structured logging primitives shared by the
+// tag service layers (log/slog handlers, record dedup).
+package logging
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "log/slog"
+    "math/rand"
+    "os"
+    "sync"
+    "time"
+
+    "go.opentelemetry.io/otel/trace" // v1.11.0
+)
+
+// defaultDedupWindow bounds how long an identical record is suppressed for.
+const defaultDedupWindow = 30 * time.Second
+
+// redactedValue replaces the value of any attribute whose key is configured
+// for redaction.
+const redactedValue = "[REDACTED]"
+
+// LoggingConfig describes how NewLogger should build a service's logger. It
+// mirrors config.LoggingConfig field-for-field; callers translate their
+// config section into this type rather than this package importing config,
+// keeping logging free of a dependency on every caller's config shape.
+type LoggingConfig struct {
+    // Level is one of "debug", "info", "warn", "error".
+    Level string
+    // Format is "json" or "text".
+    Format string
+    // Output is "stdout" or "file". FilePath is required when Output is "file".
+    Output   string
+    FilePath string
+    // SampleRate, in (0, 1], is the fraction of Debug/Info records that are
+    // actually emitted; Warn and Error are always emitted regardless.
+    SampleRate float64
+    // RedactKeys lists attribute keys whose values are replaced with
+    // redactedValue before a record is written, e.g. "encryption_key".
+    RedactKeys []string
+}
+
+// NewLogger builds a *slog.Logger from cfg: a base JSON or text handler,
+// wrapped, from the innermost layer out, with redaction, trace-ID stamping,
+// sampling, and dedup. Dedup and sampling are the outer layers, so they see a
+// record first and a record they drop never reaches trace stamping or
+// redaction; every record that is written has passed through both. The returned *slog.LevelVar backs the handler's level, so a
+// caller that wants cfg.Level to be hot-reloadable (see config.Config.Watch)
+// can call its Set method instead of rebuilding the logger.
+func NewLogger(cfg LoggingConfig) (*slog.Logger, *slog.LevelVar, error) {
+    levelVar := &slog.LevelVar{}
+    levelVar.Set(ParseLevel(cfg.Level))
+
+    w, err := openOutput(cfg.Output, cfg.FilePath)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    var handler slog.Handler
+    switch cfg.Format {
+    case "text":
+        handler = slog.NewTextHandler(w, &slog.HandlerOptions{Level: levelVar})
+    case "json", "":
+        handler = slog.NewJSONHandler(w, &slog.HandlerOptions{Level: levelVar})
+    default:
+        return nil, nil, fmt.Errorf("unknown logging format %q", cfg.Format)
+    }
+
+    if len(cfg.RedactKeys) > 0 {
+        handler = NewRedactHandler(handler, cfg.RedactKeys)
+    }
+    handler = NewTraceContextHandler(handler)
+    if cfg.SampleRate > 0 && cfg.SampleRate < 1 {
+        handler = NewSampleHandler(handler, cfg.SampleRate)
+    }
+    handler = NewDedupHandler(handler, defaultDedupWindow)
+
+    return slog.New(handler), levelVar, nil
+}
+
+// ParseLevel translates a LoggingConfig.Level string into a slog.Level.
+// Exported so a caller feeding a reloaded level string into a
+// *slog.LevelVar (see config.Config.Watch) doesn't need to re-derive this
+// mapping itself.
+func ParseLevel(level string) slog.Level {
+    switch level {
+    case "debug":
+        return slog.LevelDebug
+    case "warn":
+        return slog.LevelWarn
+    case "error":
+        return slog.LevelError
+    default:
+        return slog.LevelInfo
+    }
+}
+
+func openOutput(output, filePath string) (io.Writer, error) {
+    switch output {
+    case "file":
+        f, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+        if err != nil {
+            return nil, fmt.Errorf("failed to open log file %q: %w", filePath, err)
+        }
+        return f, nil
+    case "stdout", "":
+        return os.Stdout, nil
+    default:
+        return nil, fmt.Errorf("unknown logging output %q", output)
+    }
+}
+
+// RedactHandler wraps another slog.Handler and replaces the value of any
+// attribute whose key is in keys before forwarding the record, so a
+// misconfigured log line can't leak a secret like the encryption key.
+type RedactHandler struct {
+    next slog.Handler
+    keys map[string]bool
+}
+
+// NewRedactHandler wraps next, redacting attribute values for the given keys.
+func NewRedactHandler(next slog.Handler, keys []string) *RedactHandler {
+    set := make(map[string]bool, len(keys))
+    for _, k := range keys {
+        set[k] = true
+    }
+    return &RedactHandler{next: next, keys: set}
+}
+
+func (h *RedactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+    return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactHandler) Handle(ctx context.Context, record slog.Record) error {
+    redacted := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+    record.Attrs(func(a slog.Attr) bool {
+        if h.keys[a.Key] {
+            a.Value = slog.StringValue(redactedValue)
+        }
+        redacted.AddAttrs(a)
+        return true
+    })
+    return h.next.Handle(ctx, redacted)
+}
+
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    redacted := make([]slog.Attr, len(attrs))
+    for i, a := range attrs {
+        if h.keys[a.Key] {
+            a.Value = slog.StringValue(redactedValue)
+        }
+        redacted[i] = a
+    }
+    return &RedactHandler{next: h.next.WithAttrs(redacted), keys: h.keys}
+}
+
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+    return &RedactHandler{next: h.next.WithGroup(name), keys: h.keys}
+}
+
+// TraceContextHandler wraps another slog.Handler and stamps each record with
+// trace_id/span_id pulled from ctx's active OpenTelemetry span, if any, so a
+// log line can be correlated back to the trace that produced it.
+type TraceContextHandler struct {
+    next slog.Handler
+}
+
+// NewTraceContextHandler wraps next with trace/span ID stamping.
+func NewTraceContextHandler(next slog.Handler) *TraceContextHandler {
+    return &TraceContextHandler{next: next}
+}
+
+func (h *TraceContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+    return h.next.Enabled(ctx, level)
+}
+
+func (h *TraceContextHandler) Handle(ctx context.Context, record slog.Record) error {
+    if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+        record.AddAttrs(
+            slog.String("trace_id", sc.TraceID().String()),
+            slog.String("span_id", sc.SpanID().String()),
+        )
+    }
+    return h.next.Handle(ctx, record)
+}
+
+func (h *TraceContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    return &TraceContextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *TraceContextHandler) WithGroup(name string) slog.Handler {
+    return &TraceContextHandler{next: h.next.WithGroup(name)}
+}
+
+// SampleHandler wraps another slog.Handler and drops a 1-rate fraction of
+// Debug/Info records; Warn and Error always pass through so a noisy
+// low-severity log path can't hide an elevated error rate behind sampling.
+type SampleHandler struct {
+    next slog.Handler
+    rate float64
+}
+
+// NewSampleHandler wraps next, sampling Debug/Info records at rate.
+func NewSampleHandler(next slog.Handler, rate float64) *SampleHandler {
+    return &SampleHandler{next: next, rate: rate}
+}
+
+func (h *SampleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+    return h.next.Enabled(ctx, level)
+}
+
+func (h *SampleHandler) Handle(ctx context.Context, record slog.Record) error {
+    if record.Level < slog.LevelWarn && rand.Float64() > h.rate {
+        return nil
+    }
+    return h.next.Handle(ctx, record)
+}
+
+func (h *SampleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    return &SampleHandler{next: h.next.WithAttrs(attrs), rate: h.rate}
+}
+
+func (h *SampleHandler) WithGroup(name string) slog.Handler {
+    return &SampleHandler{next: h.next.WithGroup(name), rate: h.rate}
+}
+
+// NewJSONLogger builds the production logger: structured JSON records on w,
+// wrapped with a dedup handler so a flapping dependency (e.g. Redis) can't
+// flood the log stream with identical cache-failure lines.
+func NewJSONLogger(w io.Writer, level slog.Level) *slog.Logger {
+    handler := NewDedupHandler(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}), defaultDedupWindow)
+    return slog.New(handler)
+}
+
+// NewTextLogger builds the human-readable logger used by tests and local
+// development; it intentionally skips deduplication so test assertions see
+// every record.
+func NewTextLogger(w io.Writer, level slog.Level) *slog.Logger {
+    return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// DedupHandler wraps another slog.Handler and suppresses records that are
+// identical (same level, message, and attributes) to one already emitted
+// within window. This keeps a repeating failure - e.g. the cache being down -
+// from drowning out everything else while it recovers.
+type DedupHandler struct {
+    next   slog.Handler
+    window time.Duration
+
+    mu        sync.Mutex
+    seen      map[string]time.Time
+    lastSweep time.Time
+}
+
+// NewDedupHandler wraps next with dedup suppression over window.
+func NewDedupHandler(next slog.Handler, window time.Duration) *DedupHandler {
+    return &DedupHandler{
+        next:   next,
+        window: window,
+        seen:   make(map[string]time.Time),
+    }
+}
+
+// Enabled delegates to the wrapped handler.
+func (h *DedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+    return h.next.Enabled(ctx, level)
+}
+
+// Handle suppresses a record if an identical one was emitted within window,
+// otherwise forwards it to the wrapped handler.
+func (h *DedupHandler) Handle(ctx context.Context, record slog.Record) error {
+    key := dedupKey(record)
+    now := record.Time
+
+    h.mu.Lock()
+    last, ok := h.seen[key]
+    if ok && now.Sub(last) < h.window {
+        h.mu.Unlock()
+        return nil
+    }
+    h.seen[key] = now
+    h.sweepLocked(now)
+    h.mu.Unlock()
+
+    return h.next.Handle(ctx, record)
+}
+
+// sweepLocked drops seen entries older than window, at most once per
+// window. Called with mu held. Without this, a high-cardinality key space
+// (e.g. a message with an interpolated request ID) would grow seen without
+// bound instead of staying roughly proportional to the traffic within a
+// single window.
+func (h *DedupHandler) sweepLocked(now time.Time) {
+    if now.Sub(h.lastSweep) < h.window {
+        return
+    }
+    h.lastSweep = now
+    for key, last := range h.seen {
+        if now.Sub(last) >= h.window {
+            delete(h.seen, key)
+        }
+    }
+}
+
+// WithAttrs delegates to the wrapped handler, preserving dedup state.
+func (h *DedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    return &DedupHandler{next: h.next.WithAttrs(attrs), window: h.window, seen: h.seen}
+}
+
+// WithGroup delegates to the wrapped handler, preserving dedup state.
+func (h *DedupHandler) WithGroup(name string) slog.Handler {
+    return &DedupHandler{next: h.next.WithGroup(name), window: h.window, seen: h.seen}
+}
+
+func dedupKey(record slog.Record) string {
+    key := record.Level.String() + "|" + record.Message
+    record.Attrs(func(a slog.Attr) bool {
+        key += "|" + a.Key + "=" + a.Value.String()
+        return true
+    })
+    return key
+}
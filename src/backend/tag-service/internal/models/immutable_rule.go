@@ -0,0 +1,93 @@
+package models
+
+import (
+    "errors"
+    "regexp"
+    "strings"
+
+    "go.mongodb.org/mongo-driver/bson/primitive" // v1.11.0
+)
+
+// ImmutableRule pins tags matching both Scope and Match as immutable: once
+// enabled, UpdateTag/DeleteTag and BatchCreateTags's replace-existing path
+// refuse to touch any tag the rule matches for as long as the rule stays
+// enabled. Matches does not look at the tag's ExpiresAt, so an expired tag
+// stays pinned too.
+type ImmutableRule struct {
+    ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+    Scope    RuleScope          `bson:"scope" json:"scope"`
+    Match    RuleMatch          `bson:"match" json:"match"`
+    // Priority breaks ties when more than one rule matches a tag; higher
+    // values are evaluated first, but the outcome (blocked) is the same
+    // regardless of which matching rule is reported.
+    Priority int  `bson:"priority" json:"priority"`
+    Enabled  bool `bson:"enabled" json:"enabled"`
+}
+
+// RuleScope narrows which tags a rule considers at all, before Match is
+// evaluated against the remainder. An empty field matches everything.
+type RuleScope struct {
+    // GeohashPrefix restricts the rule to tags whose location geohash
+    // starts with this prefix, e.g. "dr5r9".
+    GeohashPrefix string `bson:"geohash_prefix,omitempty" json:"geohash_prefix,omitempty"`
+    // Categories restricts the rule to tags whose Category is in this list.
+    Categories []string `bson:"categories,omitempty" json:"categories,omitempty"`
+    // CreatorStatusLevel restricts the rule to tags whose CreatorStatusLevel
+    // equals this value, e.g. "elite".
+    CreatorStatusLevel string `bson:"creator_status_level,omitempty" json:"creator_status_level,omitempty"`
+}
+
+// RuleMatch is the content-level condition a tag must satisfy, within
+// Scope, for the rule to fire.
+type RuleMatch struct {
+    // ContentPattern, if set, is a regular expression the tag's Content must
+    // match. Compile must be called before Matches will honor it.
+    ContentPattern string `bson:"content_pattern,omitempty" json:"content_pattern,omitempty"`
+
+    compiled *regexp.Regexp
+}
+
+// Compile parses ContentPattern, if set, so Matches can use it. Callers that
+// load rules from storage (e.g. repository.ActiveImmutableRules) must call
+// this once per rule before evaluating it.
+func (r *ImmutableRule) Compile() error {
+    if r.Match.ContentPattern == "" {
+        return nil
+    }
+    compiled, err := regexp.Compile(r.Match.ContentPattern)
+    if err != nil {
+        return errors.New("invalid content pattern: " + err.Error())
+    }
+    r.Match.compiled = compiled
+    return nil
+}
+
+// Matches reports whether tag falls within Scope and satisfies Match. A
+// disabled rule never matches.
+func (r *ImmutableRule) Matches(tag *Tag) bool {
+    if !r.Enabled {
+        return false
+    }
+    if r.Scope.GeohashPrefix != "" && !strings.HasPrefix(tag.Location.Geohash, r.Scope.GeohashPrefix) {
+        return false
+    }
+    if len(r.Scope.Categories) > 0 && !containsCategory(r.Scope.Categories, tag.Category) {
+        return false
+    }
+    if r.Scope.CreatorStatusLevel != "" && r.Scope.CreatorStatusLevel != tag.CreatorStatusLevel {
+        return false
+    }
+    if r.Match.compiled != nil && !r.Match.compiled.MatchString(tag.Content) {
+        return false
+    }
+    return true
+}
+
+func containsCategory(categories []string, category string) bool {
+    for _, c := range categories {
+        if c == category {
+            return true
+        }
+    }
+    return false
+}
@@ -37,6 +37,12 @@ type Location struct {
     Longitude float64 `bson:"longitude" json:"longitude"`
     Altitude  float64 `bson:"altitude" json:"altitude"`
     Geohash   string  `bson:"geohash" json:"geohash"`
+
+    // H3Cells holds the H3 cell ID at every indexed resolution (see
+    // spatial.MinResolution/MaxResolution), keyed by resolution. Populated
+    // at write time so GetNearbyTags can narrow candidates via an indexed
+    // $in on the coarse resolution before refining with calculateDistance.
+    H3Cells map[int]uint64 `bson:"h3_cells,omitempty" json:"h3_cells,omitempty"`
 }
 
 // Validate ensures location coordinates are within acceptable ranges
@@ -56,6 +62,12 @@ func (l *Location) Validate() error {
     return nil
 }
 
+// DistanceTo returns the 3D distance in meters between l and other,
+// combining haversine surface distance with the altitude difference.
+func (l *Location) DistanceTo(other Location) float64 {
+    return calculateDistance(*l, other)
+}
+
 // ToGeoJSON converts location to GeoJSON format for MongoDB spatial queries
 func (l *Location) ToGeoJSON() map[string]interface{} {
     return map[string]interface{}{
@@ -72,6 +84,11 @@ func (l *Location) ToGeoJSON() map[string]interface{} {
 type Tag struct {
     ID              primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
     CreatorID       string                `bson:"creator_id" json:"creator_id"`
+    // CreatorStatusLevel is the creator's status tier at the time the tag
+    // was written, e.g. "elite"/"regular" - see filter.Viewer.StatusLevel
+    // for the equivalent concept on the viewing side. Scoped rule matching
+    // (models.RuleScope) is the main consumer.
+    CreatorStatusLevel string            `bson:"creator_status_level,omitempty" json:"creator_status_level,omitempty"`
     Location        Location              `bson:"location" json:"location"`
     Content         string                `bson:"content" json:"content"`
     MediaURLs       []string              `bson:"media_urls" json:"media_urls"`
@@ -83,6 +100,14 @@ type Tag struct {
     Status          int                   `bson:"status" json:"status"`
     InteractionCount int                  `bson:"interaction_count" json:"interaction_count"`
     Metadata        map[string]interface{} `bson:"metadata" json:"metadata"`
+
+    // Signature, SignatureAlg, and SignerKeyID let signing.Verifier confirm
+    // a tag's creator-controlled fields were signed by the key pinned to
+    // CreatorID - see signing.CanonicalTagBytes for exactly which fields
+    // the signature covers.
+    Signature    []byte `bson:"signature,omitempty" json:"signature,omitempty"`
+    SignatureAlg string `bson:"signature_alg,omitempty" json:"signature_alg,omitempty"`
+    SignerKeyID  string `bson:"signer_key_id,omitempty" json:"signer_key_id,omitempty"`
 }
 
 // Validate performs comprehensive validation of tag data
@@ -123,17 +148,15 @@ func (t *Tag) IsExpired() bool {
     return time.Now().After(t.ExpiresAt)
 }
 
-// IsVisible checks if tag is visible to a user based on status level and distance
+// IsVisible checks whether tag is active and within range of userLocation.
+// Tier, category, geofence and other policy-driven visibility rules are no
+// longer hardcoded here - they are expressed as a filter.TagFilter chain
+// (see internal/filter) that TagService applies on top of this base check.
 func (t *Tag) IsVisible(userStatusLevel string, userLocation Location) bool {
     if t.Status != TagStatusActive {
         return false
     }
 
-    // Check visibility level restrictions
-    if t.Visibility == TagVisibilityEliteOnly && userStatusLevel != "elite" {
-        return false
-    }
-
     // Calculate distance between user and tag
     distance := calculateDistance(t.Location, userLocation)
     return distance <= t.VisibilityRadius
@@ -0,0 +1,115 @@
+// Package policy implements the immutable-tag rule engine: operators
+// declare models.ImmutableRule documents pinning tags matching a scope/
+// content selector as immutable, and ImmutablePolicyEvaluator together with
+// the PushMiddleware/DeleteMiddleware helpers enforce them in front of
+// TagService's mutating operations - the same enforcement Harbor's
+// push/delete middleware gives artifact tags, just ahead of the repository
+// call rather than the registry's blob store.
+package policy
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+
+    "go.mongodb.org/mongo-driver/bson/primitive" // v1.11.0
+
+    "../models"
+)
+
+// ErrTagImmutable is wrapped into the error PushMiddleware/DeleteMiddleware
+// return when a matching enabled rule blocks the operation.
+var ErrTagImmutable = errors.New("tag is immutable")
+
+// ImmutablePolicyEvaluator decides whether tag, as currently stored, is
+// pinned immutable by an enabled rule. A nil rule means no rule matched.
+type ImmutablePolicyEvaluator interface {
+    Evaluate(ctx context.Context, tag *models.Tag) (*models.ImmutableRule, error)
+}
+
+// RuleSource is the subset of repository.MongoRepository RuleEvaluator
+// needs, so it can be exercised in tests without a live MongoDB.
+type RuleSource interface {
+    ActiveImmutableRules(ctx context.Context) ([]*models.ImmutableRule, error)
+}
+
+// RuleEvaluator is the default ImmutablePolicyEvaluator, backed by whatever
+// rules source stores immutable_rules.
+type RuleEvaluator struct {
+    rules RuleSource
+}
+
+// NewRuleEvaluator builds a RuleEvaluator that loads rules from rules on
+// every Evaluate call, so newly created or disabled rules take effect
+// without restarting the service.
+func NewRuleEvaluator(rules RuleSource) *RuleEvaluator {
+    return &RuleEvaluator{rules: rules}
+}
+
+// Evaluate loads every enabled rule and returns the highest-priority one
+// that matches tag, or nil if none do.
+func (e *RuleEvaluator) Evaluate(ctx context.Context, tag *models.Tag) (*models.ImmutableRule, error) {
+    rules, err := e.rules.ActiveImmutableRules(ctx)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load immutable rules: %w", err)
+    }
+
+    sort.Slice(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+    for _, rule := range rules {
+        if rule.Matches(tag) {
+            return rule, nil
+        }
+    }
+    return nil, nil
+}
+
+// UpdateFunc is the shape of the repository call PushMiddleware wraps -
+// repo.UpdateTag, or a "replace existing" write in a batch path.
+type UpdateFunc func(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+
+// DeleteFunc is the shape of the repository call DeleteMiddleware wraps -
+// repo.DeleteTag.
+type DeleteFunc func(ctx context.Context, id primitive.ObjectID) error
+
+// PushMiddleware wraps next so it only runs when current - the tag as
+// currently stored, or nil if there is nothing on record yet to protect -
+// isn't pinned immutable by an enabled rule. Blocking here rather than in
+// the gRPC/HTTP handlers means both get the same enforcement for free,
+// since the HTTP gateway forwards straight into the same TagService calls.
+func PushMiddleware(evaluator ImmutablePolicyEvaluator, current *models.Tag, next UpdateFunc) UpdateFunc {
+    return func(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+        if current != nil {
+            rule, err := evaluator.Evaluate(ctx, current)
+            if err != nil {
+                return nil, err
+            }
+            if rule != nil {
+                return nil, blockedErr(rule)
+            }
+        }
+        return next(ctx, tag)
+    }
+}
+
+// DeleteMiddleware is PushMiddleware's counterpart for deletes; current is
+// the tag being deleted, fetched by the caller ahead of time since id alone
+// isn't enough to evaluate rule scope/content.
+func DeleteMiddleware(evaluator ImmutablePolicyEvaluator, current *models.Tag, next DeleteFunc) DeleteFunc {
+    return func(ctx context.Context, id primitive.ObjectID) error {
+        if current != nil {
+            rule, err := evaluator.Evaluate(ctx, current)
+            if err != nil {
+                return err
+            }
+            if rule != nil {
+                return blockedErr(rule)
+            }
+        }
+        return next(ctx, id)
+    }
+}
+
+func blockedErr(rule *models.ImmutableRule) error {
+    return fmt.Errorf("%w: blocked by rule %q", ErrTagImmutable, rule.ID.Hex())
+}
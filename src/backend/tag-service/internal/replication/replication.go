@@ -0,0 +1,236 @@
+// Package replication duplicates tag writes to neighbor spatial-tag nodes,
+// modeled on Kraken's "DuplicatePut to neighbor" pattern: once a primary
+// write succeeds, TagService hands the same operation to every neighbor
+// NeighborProvider resolves for the tag, staggered so a write burst doesn't
+// thundering-herd the whole neighbor set at once. A neighbor failure is
+// enqueued into a durable retry Outbox rather than failing the primary
+// write.
+package replication
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "log/slog"
+    "net/http"
+    "os"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson/primitive" // v1.11.0
+
+    "../logging"
+    "../models"
+)
+
+// OpType identifies which TagService mutation an Op replays on a neighbor.
+type OpType string
+
+const (
+    OpCreate      OpType = "create"
+    OpUpdate      OpType = "update"
+    OpDelete      OpType = "delete"
+    OpBatchCreate OpType = "batch_create"
+)
+
+// Op is a single replicated write, handed to every resolved neighbor's
+// NeighborClient. Only the fields relevant to Type are populated.
+type Op struct {
+    Type  OpType
+    Tag   *models.Tag
+    Tags  []*models.Tag
+    TagID primitive.ObjectID
+}
+
+// representativeTag returns the tag Neighbors should resolve neighbors
+// against - the one being written, or the first of a batch.
+func (op Op) representativeTag() *models.Tag {
+    if op.Tag != nil {
+        return op.Tag
+    }
+    if len(op.Tags) > 0 {
+        return op.Tags[0]
+    }
+    return nil
+}
+
+// Neighbor identifies one peer spatial-tag node a write can be replicated to.
+type Neighbor struct {
+    ID   string
+    Addr string
+}
+
+// NeighborProvider resolves which neighbor nodes should receive a
+// replicated write for tag - potentially geohash-aware, e.g. narrowing to
+// nodes that own the geohash cells adjacent to tag's location rather than
+// every configured neighbor.
+type NeighborProvider interface {
+    Neighbors(ctx context.Context, tag *models.Tag) ([]Neighbor, error)
+}
+
+// StaticNeighborProvider returns a fixed neighbor set, optionally narrowed
+// by geohash prefix ownership.
+type StaticNeighborProvider struct {
+    // Peers is every configured neighbor node.
+    Peers []Neighbor
+    // GeohashPrefixes maps a Neighbor.ID to the geohash prefixes it owns.
+    // A neighbor absent from this map is treated as owning everything, so
+    // deployments that don't care about geohash-aware routing can leave it
+    // nil and replicate to every configured peer.
+    GeohashPrefixes map[string][]string
+}
+
+// NewStaticNeighborProvider builds a StaticNeighborProvider that replicates
+// to every neighbor in neighbors regardless of geohash.
+func NewStaticNeighborProvider(neighbors []Neighbor) *StaticNeighborProvider {
+    return &StaticNeighborProvider{Peers: neighbors}
+}
+
+func (p *StaticNeighborProvider) Neighbors(ctx context.Context, tag *models.Tag) ([]Neighbor, error) {
+    if tag == nil || len(p.GeohashPrefixes) == 0 {
+        return p.Peers, nil
+    }
+
+    var matched []Neighbor
+    for _, n := range p.Peers {
+        prefixes, ok := p.GeohashPrefixes[n.ID]
+        if !ok {
+            matched = append(matched, n)
+            continue
+        }
+        for _, prefix := range prefixes {
+            if hasGeohashPrefix(tag.Location.Geohash, prefix) {
+                matched = append(matched, n)
+                break
+            }
+        }
+    }
+    return matched, nil
+}
+
+func hasGeohashPrefix(geohash, prefix string) bool {
+    return len(geohash) >= len(prefix) && geohash[:len(prefix)] == prefix
+}
+
+// NeighborClient duplicates a single Op to one neighbor node.
+type NeighborClient interface {
+    DuplicatePut(ctx context.Context, neighbor Neighbor, op Op) error
+}
+
+// replicationPath is the internal HTTP endpoint HTTPNeighborClient posts a
+// replicated Op to on each neighbor.
+const replicationPath = "/internal/v1/tags/replicate"
+
+// HTTPNeighborClient is the default NeighborClient: it POSTs the JSON-
+// encoded Op to neighbor.Addr+replicationPath, the internal endpoint every
+// spatial-tag node exposes alongside its public gRPC/REST surface.
+type HTTPNeighborClient struct {
+    httpClient *http.Client
+}
+
+// NewHTTPNeighborClient builds an HTTPNeighborClient with the given
+// per-request timeout.
+func NewHTTPNeighborClient(timeout time.Duration) *HTTPNeighborClient {
+    return &HTTPNeighborClient{httpClient: &http.Client{Timeout: timeout}}
+}
+
+func (c *HTTPNeighborClient) DuplicatePut(ctx context.Context, neighbor Neighbor, op Op) error {
+    body, err := json.Marshal(op)
+    if err != nil {
+        return fmt.Errorf("failed to marshal replicated op: %w", err)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, neighbor.Addr+replicationPath, bytes.NewReader(body))
+    if err != nil {
+        return fmt.Errorf("failed to build replication request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return fmt.Errorf("failed to reach neighbor %s: %w", neighbor.ID, err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= 300 {
+        return fmt.Errorf("neighbor %s rejected replicated op with status %d", neighbor.ID, resp.StatusCode)
+    }
+    return nil
+}
+
+// OutboxEntry is a replication attempt that failed and needs retrying.
+type OutboxEntry struct {
+    Neighbor  Neighbor
+    Op        Op
+    LastError string
+}
+
+// Outbox durably persists OutboxEntry so a failed replication survives a
+// process restart instead of being dropped; see
+// repository.MongoRepository.Enqueue for the MongoDB-backed
+// implementation TagReplicator is normally wired to.
+type Outbox interface {
+    Enqueue(ctx context.Context, entry OutboxEntry) error
+}
+
+// TagReplicator fans a successful primary write out to every neighbor
+// NeighborProvider resolves, staggered by DuplicateReplicateStagger so a
+// burst of writes doesn't send every neighbor a wave of requests at once.
+// Replicate never returns an error: neighbor failures go to Outbox instead
+// of the primary write's response.
+type TagReplicator struct {
+    neighbors NeighborProvider
+    client    NeighborClient
+    outbox    Outbox
+    stagger   time.Duration
+    Logger    *slog.Logger
+}
+
+// NewTagReplicator builds a TagReplicator. If logger is nil, a default JSON
+// logger writing to stderr is used.
+func NewTagReplicator(neighbors NeighborProvider, client NeighborClient, outbox Outbox, stagger time.Duration, logger *slog.Logger) *TagReplicator {
+    if logger == nil {
+        logger = logging.NewJSONLogger(os.Stderr, slog.LevelInfo)
+    }
+    return &TagReplicator{
+        neighbors: neighbors,
+        client:    client,
+        outbox:    outbox,
+        stagger:   stagger,
+        Logger:    logger,
+    }
+}
+
+// Replicate resolves op's neighbor set and duplicates op to each one in
+// turn, sleeping DuplicateReplicateStagger between neighbors after the
+// first so the fan-out spreads out over time instead of bursting. A
+// neighbor that errors is enqueued into the outbox and does not stop the
+// remaining neighbors from being tried.
+func (r *TagReplicator) Replicate(ctx context.Context, op Op) {
+    neighbors, err := r.neighbors.Neighbors(ctx, op.representativeTag())
+    if err != nil {
+        r.Logger.WarnContext(ctx, "failed to resolve replication neighbors",
+            "operation", "replicate", "op_type", string(op.Type), "error", err)
+        return
+    }
+
+    for i, neighbor := range neighbors {
+        if i > 0 && r.stagger > 0 {
+            select {
+            case <-time.After(r.stagger):
+            case <-ctx.Done():
+                return
+            }
+        }
+
+        if err := r.client.DuplicatePut(ctx, neighbor, op); err != nil {
+            r.Logger.WarnContext(ctx, "neighbor replication failed, enqueueing retry",
+                "operation", "replicate", "op_type", string(op.Type), "neighbor_id", neighbor.ID, "error", err)
+            entry := OutboxEntry{Neighbor: neighbor, Op: op, LastError: err.Error()}
+            if err := r.outbox.Enqueue(ctx, entry); err != nil {
+                r.Logger.ErrorContext(ctx, "failed to enqueue replication retry",
+                    "operation", "replicate", "neighbor_id", neighbor.ID, "error", err)
+            }
+        }
+    }
+}
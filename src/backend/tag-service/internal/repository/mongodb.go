@@ -3,6 +3,10 @@ package repository
 import (
     "context"
     "errors"
+    "fmt"
+    "log/slog"
+    "os"
+    "regexp"
     "sync"
     "time"
 
@@ -10,21 +14,49 @@ import (
     "go.mongodb.org/mongo-driver/bson" // v1.11.0
     "go.mongodb.org/mongo-driver/bson/primitive" // v1.11.0
     "go.mongodb.org/mongo-driver/mongo/options" // v1.11.0
-    "github.com/opentracing/opentracing-go" // v1.2.0
     "github.com/prometheus/client_golang/prometheus" // v1.11.0
+    "go.opentelemetry.io/otel" // v1.11.0
+    "go.opentelemetry.io/otel/attribute" // v1.11.0
+    "go.opentelemetry.io/otel/trace" // v1.11.0
 
     "../models"
     "../config"
+    "../logging"
+    "../replication"
+    "../retention"
+    "../spatial"
 )
 
 const (
     locationIndexName    = "location_2dsphere"
     expirationIndexName = "expires_at_1"
+    h3CellIndexName     = "h3_cell_coarse_1"
     defaultQueryTimeout = 10 * time.Second
     maxRetries         = 3
     batchSize         = 1000
+    reindexBatchSize    = 500
+
+    // immutableRulesCollectionName holds the rules policy.RuleEvaluator
+    // checks tags against; see CreateImmutableRule/ActiveImmutableRules.
+    immutableRulesCollectionName = "immutable_rules"
+
+    // replicationOutboxCollectionName holds failed neighbor-replication
+    // attempts queued for retry; see Enqueue.
+    replicationOutboxCollectionName = "replication_outbox"
+
+    // retentionRulesCollectionName holds the rules service.RetentionService
+    // sweeps against; see CreateRetentionRule/RetentionRule/ActiveRetentionRules.
+    retentionRulesCollectionName = "retention_rules"
 )
 
+// ErrDuplicateTag is returned by CreateTag/BatchCreateTags when an insert
+// collides with an existing unique index entry.
+var ErrDuplicateTag = errors.New("duplicate tag")
+
+// ErrTagNotFound is returned by UpdateTag/DeleteTag when the targeted tag ID
+// has no matching document.
+var ErrTagNotFound = errors.New("tag not found")
+
 // Metrics collectors
 var (
     queryDuration = prometheus.NewHistogramVec(
@@ -47,21 +79,37 @@ var (
 
 // MongoRepository implements the tag repository interface using MongoDB
 type MongoRepository struct {
-    collection *mongo.Collection
-    config     *config.Config
-    bufferPool sync.Pool
+    collection      *mongo.Collection
+    rulesCollection *mongo.Collection
+    outboxCollection *mongo.Collection
+    retentionRulesCollection *mongo.Collection
+    config          *config.Config
+    bufferPool      sync.Pool
+    index           spatial.Index
+    tracer          trace.Tracer
+    Logger          *slog.Logger
 }
 
-// NewMongoRepository creates a new MongoDB repository instance
-func NewMongoRepository(client *mongo.Client, cfg *config.Config) (*MongoRepository, error) {
+// NewMongoRepository creates a new MongoDB repository instance. If logger is
+// nil, a default JSON logger writing to stderr is used.
+func NewMongoRepository(client *mongo.Client, cfg *config.Config, logger *slog.Logger) (*MongoRepository, error) {
     if client == nil {
         return nil, errors.New("mongodb client is required")
     }
+    if logger == nil {
+        logger = logging.NewJSONLogger(os.Stderr, slog.LevelInfo)
+    }
 
     // Initialize repository
     repo := &MongoRepository{
-        collection: client.Database(cfg.Mongo.Database).Collection(cfg.Mongo.Collection),
-        config:     cfg,
+        collection:       client.Database(cfg.Mongo.Database).Collection(cfg.Mongo.Collection),
+        rulesCollection:  client.Database(cfg.Mongo.Database).Collection(immutableRulesCollectionName),
+        outboxCollection: client.Database(cfg.Mongo.Database).Collection(replicationOutboxCollectionName),
+        retentionRulesCollection: client.Database(cfg.Mongo.Database).Collection(retentionRulesCollectionName),
+        config:           cfg,
+        index:           spatial.NewH3Index(cfg.Tag.IndexResolution),
+        tracer:          otel.Tracer("spatial-tag/repository"),
+        Logger:          logger,
         bufferPool: sync.Pool{
             New: func() interface{} {
                 return make([]models.Tag, 0, batchSize)
@@ -104,10 +152,20 @@ func (r *MongoRepository) ensureIndexes(ctx context.Context) error {
             SetBackground(true),
     }
 
+    // Create index on the coarse H3 cell so GetNearbyTags can narrow
+    // candidates with an indexed $in before the haversine refinement.
+    h3CellIndex := mongo.IndexModel{
+        Keys: bson.D{{Key: r.h3CellField(), Value: 1}},
+        Options: options.Index().
+            SetName(h3CellIndexName).
+            SetBackground(true),
+    }
+
     // Create indexes
     _, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
         locationIndex,
         expirationIndex,
+        h3CellIndex,
     })
 
     if err != nil {
@@ -119,14 +177,34 @@ func (r *MongoRepository) ensureIndexes(ctx context.Context) error {
     return nil
 }
 
-// GetNearbyTags retrieves tags near a given location with status filtering
+// h3CellField returns the bson field storing the H3 cell at r.index's
+// configured resolution (cfg.Tag.IndexResolution), e.g. "location.h3_cells.8".
+func (r *MongoRepository) h3CellField() string {
+    return fmt.Sprintf("location.h3_cells.%d", r.index.Resolution())
+}
+
+// GetNearbyTags retrieves tags near a given location with status filtering.
+// Candidates are first narrowed to the H3 cells covering the query radius
+// via an indexed $in and $geoNear's surface distance, then the 3D haversine
+// distance (models.Location.DistanceTo) refines what remains, so the full
+// collection is never scanned for dense metro areas.
 func (r *MongoRepository) GetNearbyTags(ctx context.Context, location models.Location, radius float64, userStatusLevel string) ([]*models.Tag, error) {
-    span, ctx := opentracing.StartSpanFromContext(ctx, "MongoRepository.GetNearbyTags")
-    defer span.Finish()
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.GetNearbyTags", trace.WithAttributes(
+        attribute.String("mongo.collection", r.collection.Name()),
+        attribute.String("tag.status", "active"),
+        attribute.Float64("geo.radius_meters", radius),
+    ))
+    defer span.End()
 
     timer := prometheus.NewTimer(queryDuration.WithLabelValues("get_nearby"))
     defer timer.ObserveDuration()
 
+    coveringCells, err := r.index.Cover(location, radius)
+    if err != nil {
+        tagOperations.WithLabelValues("get_nearby", "failure").Inc()
+        return nil, fmt.Errorf("failed to compute H3 cover: %w", err)
+    }
+
     // Create aggregation pipeline
     pipeline := mongo.Pipeline{
         {{Key: "$geoNear", Value: bson.D{
@@ -135,6 +213,7 @@ func (r *MongoRepository) GetNearbyTags(ctx context.Context, location models.Loc
             {Key: "maxDistance", Value: radius},
             {Key: "spherical", Value: true},
             {Key: "query", Value: bson.D{
+                {Key: r.h3CellField(), Value: bson.D{{Key: "$in", Value: coveringCells}}},
                 {Key: "status", Value: models.TagStatusActive},
                 {Key: "expires_at", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
                 {Key: "$or", Value: []bson.D{
@@ -171,14 +250,115 @@ func (r *MongoRepository) GetNearbyTags(ctx context.Context, location models.Loc
         tags[i] = &results[i]
     }
 
+    // Refine $geoNear's surface-distance matches with the 3D haversine
+    // distance, which also accounts for the altitude difference.
+    refined := tags[:0]
+    for _, tag := range tags {
+        if location.DistanceTo(tag.Location) <= radius {
+            refined = append(refined, tag)
+        }
+    }
+    tags = refined
+
+    span.SetAttributes(attribute.Int("mongo.result_count", len(tags)))
     tagOperations.WithLabelValues("get_nearby", "success").Inc()
     return tags, nil
 }
 
+// GetNearbyTagsGeoWithin retrieves tags using a plain $geoWithin scan with no
+// H3 pre-filtering. It exists purely as the pre-H3 baseline for
+// BenchmarkGeoWithinNearbyQuery; GetNearbyTags is the path served in
+// production.
+func (r *MongoRepository) GetNearbyTagsGeoWithin(ctx context.Context, location models.Location, radius float64, userStatusLevel string) ([]*models.Tag, error) {
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.GetNearbyTagsGeoWithin")
+    defer span.End()
+
+    timer := prometheus.NewTimer(queryDuration.WithLabelValues("get_nearby_geowithin"))
+    defer timer.ObserveDuration()
+
+    ctx, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
+    defer cancel()
+
+    filter := bson.D{
+        {Key: "location", Value: bson.D{{Key: "$geoWithin", Value: bson.D{
+            {Key: "$centerSphere", Value: bson.A{
+                bson.A{location.Longitude, location.Latitude},
+                radius / 6378100.0, // radians, Earth radius in meters
+            }},
+        }}}},
+        {Key: "status", Value: models.TagStatusActive},
+        {Key: "expires_at", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+    }
+
+    cursor, err := r.collection.Find(ctx, filter)
+    if err != nil {
+        tagOperations.WithLabelValues("get_nearby_geowithin", "failure").Inc()
+        return nil, err
+    }
+    defer cursor.Close(ctx)
+
+    var results []models.Tag
+    if err := cursor.All(ctx, &results); err != nil {
+        tagOperations.WithLabelValues("get_nearby_geowithin", "failure").Inc()
+        return nil, err
+    }
+
+    tags := make([]*models.Tag, len(results))
+    for i := range results {
+        tags[i] = &results[i]
+    }
+
+    tagOperations.WithLabelValues("get_nearby_geowithin", "success").Inc()
+    return tags, nil
+}
+
+// ReindexH3Cells is a background job that backfills location.h3_cells on
+// rows written before the H3 index was introduced. It processes documents
+// in bounded batches so it can run alongside live traffic.
+func (r *MongoRepository) ReindexH3Cells(ctx context.Context) (int64, error) {
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.ReindexH3Cells")
+    defer span.End()
+
+    filter := bson.D{{Key: r.h3CellField(), Value: bson.D{{Key: "$exists", Value: false}}}}
+    opts := options.Find().SetBatchSize(reindexBatchSize).SetNoCursorTimeout(true)
+
+    cursor, err := r.collection.Find(ctx, filter, opts)
+    if err != nil {
+        tagOperations.WithLabelValues("reindex_h3", "failure").Inc()
+        return 0, err
+    }
+    defer cursor.Close(ctx)
+
+    var reindexed int64
+    for cursor.Next(ctx) {
+        var tag models.Tag
+        if err := cursor.Decode(&tag); err != nil {
+            continue
+        }
+
+        cells, err := r.index.CellIDs(tag.Location)
+        if err != nil {
+            continue
+        }
+
+        _, err = r.collection.UpdateOne(ctx,
+            bson.D{{Key: "_id", Value: tag.ID}},
+            bson.D{{Key: "$set", Value: bson.D{{Key: "location.h3_cells", Value: cells}}}},
+        )
+        if err != nil {
+            continue
+        }
+        reindexed++
+    }
+
+    tagOperations.WithLabelValues("reindex_h3", "success").Add(float64(reindexed))
+    return reindexed, nil
+}
+
 // CleanupExpiredTags removes expired tags in batches
 func (r *MongoRepository) CleanupExpiredTags(ctx context.Context) error {
-    span, ctx := opentracing.StartSpanFromContext(ctx, "MongoRepository.CleanupExpiredTags")
-    defer span.Finish()
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.CleanupExpiredTags")
+    defer span.End()
 
     timer := prometheus.NewTimer(queryDuration.WithLabelValues("cleanup"))
     defer timer.ObserveDuration()
@@ -203,21 +383,346 @@ func (r *MongoRepository) CleanupExpiredTags(ctx context.Context) error {
     for cursor.Next(ctx) {
         var tag models.Tag
         if err := cursor.Decode(&tag); err != nil {
+            r.Logger.WarnContext(ctx, "failed to decode expired tag document, skipping",
+                "operation", "cleanup_expired_tags", "error", err)
             continue
         }
 
-        _, err := r.collection.UpdateOne(ctx, 
+        _, err := r.collection.UpdateOne(ctx,
             bson.D{{Key: "_id", Value: tag.ID}},
             bson.D{{Key: "$set", Value: bson.D{{Key: "status", Value: models.TagStatusExpired}}}},
         )
         if err != nil {
+            r.Logger.WarnContext(ctx, "failed to mark expired tag as expired, skipping",
+                "operation", "cleanup_expired_tags", "tag_id", tag.ID.Hex(), "error", err)
             continue
         }
         deleteCount++
     }
 
+    r.Logger.InfoContext(ctx, "expired tags cleanup complete",
+        "operation", "cleanup_expired_tags", "expired_count", deleteCount)
     tagOperations.WithLabelValues("cleanup", "success").
         Add(float64(deleteCount))
 
     return nil
-}
\ No newline at end of file
+}
+
+// Ping verifies the MongoDB connection is reachable, for use by health probes.
+func (r *MongoRepository) Ping(ctx context.Context) error {
+    return r.collection.Database().Client().Ping(ctx, nil)
+}
+
+// HasRequiredIndexes reports whether the spatial, expiration, and H3-cell
+// indexes ensureIndexes creates are all present, so a health probe can
+// detect a collection that was dropped or recreated out from under a running
+// process rather than relying solely on connectivity.
+func (r *MongoRepository) HasRequiredIndexes(ctx context.Context) (bool, error) {
+    cursor, err := r.collection.Indexes().List(ctx)
+    if err != nil {
+        return false, err
+    }
+    defer cursor.Close(ctx)
+
+    required := map[string]bool{
+        locationIndexName:   false,
+        expirationIndexName: false,
+        h3CellIndexName:     false,
+    }
+
+    for cursor.Next(ctx) {
+        var idx bson.M
+        if err := cursor.Decode(&idx); err != nil {
+            return false, err
+        }
+        if name, ok := idx["name"].(string); ok {
+            if _, tracked := required[name]; tracked {
+                required[name] = true
+            }
+        }
+    }
+
+    for _, present := range required {
+        if !present {
+            return false, nil
+        }
+    }
+    return true, nil
+}
+
+// GetTagByID fetches a single tag as currently stored, for callers -
+// notably the immutable-tag policy check in TagService's UpdateTag/
+// DeleteTag/BatchCreateTags - that need the tag on record rather than what
+// a caller is about to write.
+func (r *MongoRepository) GetTagByID(ctx context.Context, id primitive.ObjectID) (*models.Tag, error) {
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.GetTagByID", trace.WithAttributes(attribute.String("tag.id", id.Hex())))
+    defer span.End()
+
+    timer := prometheus.NewTimer(queryDuration.WithLabelValues("get_by_id"))
+    defer timer.ObserveDuration()
+
+    var tag models.Tag
+    if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&tag); err != nil {
+        tagOperations.WithLabelValues("get_by_id", "failure").Inc()
+        return nil, fmt.Errorf("failed to get tag: %w", err)
+    }
+
+    tagOperations.WithLabelValues("get_by_id", "success").Inc()
+    return &tag, nil
+}
+
+// CreateTag inserts a new tag.
+func (r *MongoRepository) CreateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.CreateTag")
+    defer span.End()
+
+    timer := prometheus.NewTimer(queryDuration.WithLabelValues("create"))
+    defer timer.ObserveDuration()
+
+    cells, err := r.index.CellIDs(tag.Location)
+    if err != nil {
+        tagOperations.WithLabelValues("create", "failure").Inc()
+        return nil, fmt.Errorf("failed to compute H3 cells: %w", err)
+    }
+    tag.Location.H3Cells = cells
+
+    res, err := r.collection.InsertOne(ctx, tag)
+    if err != nil {
+        tagOperations.WithLabelValues("create", "failure").Inc()
+        if mongo.IsDuplicateKeyError(err) {
+            return nil, ErrDuplicateTag
+        }
+        return nil, fmt.Errorf("failed to create tag: %w", err)
+    }
+
+    tag.ID = res.InsertedID.(primitive.ObjectID)
+    tagOperations.WithLabelValues("create", "success").Inc()
+    return tag, nil
+}
+
+// UpdateTag replaces an existing tag document in place.
+func (r *MongoRepository) UpdateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.UpdateTag", trace.WithAttributes(attribute.String("tag.id", tag.ID.Hex())))
+    defer span.End()
+
+    timer := prometheus.NewTimer(queryDuration.WithLabelValues("update"))
+    defer timer.ObserveDuration()
+
+    cells, err := r.index.CellIDs(tag.Location)
+    if err != nil {
+        tagOperations.WithLabelValues("update", "failure").Inc()
+        return nil, fmt.Errorf("failed to compute H3 cells: %w", err)
+    }
+    tag.Location.H3Cells = cells
+
+    res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": tag.ID}, tag)
+    if err != nil {
+        tagOperations.WithLabelValues("update", "failure").Inc()
+        return nil, fmt.Errorf("failed to update tag: %w", err)
+    }
+    if res.MatchedCount == 0 {
+        tagOperations.WithLabelValues("update", "failure").Inc()
+        return nil, ErrTagNotFound
+    }
+
+    tagOperations.WithLabelValues("update", "success").Inc()
+    return tag, nil
+}
+
+// DeleteTag removes a single tag by ID.
+func (r *MongoRepository) DeleteTag(ctx context.Context, id primitive.ObjectID) error {
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.DeleteTag", trace.WithAttributes(attribute.String("tag.id", id.Hex())))
+    defer span.End()
+
+    timer := prometheus.NewTimer(queryDuration.WithLabelValues("delete"))
+    defer timer.ObserveDuration()
+
+    res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+    if err != nil {
+        tagOperations.WithLabelValues("delete", "failure").Inc()
+        return fmt.Errorf("failed to delete tag: %w", err)
+    }
+    if res.DeletedCount == 0 {
+        tagOperations.WithLabelValues("delete", "failure").Inc()
+        return ErrTagNotFound
+    }
+
+    tagOperations.WithLabelValues("delete", "success").Inc()
+    return nil
+}
+
+// BatchCreateTags inserts multiple tags in one round trip.
+func (r *MongoRepository) BatchCreateTags(ctx context.Context, tags []*models.Tag) ([]*models.Tag, error) {
+    ctx, span := r.tracer.Start(ctx, "MongoRepository.BatchCreateTags", trace.WithAttributes(attribute.Int("batch_size", len(tags))))
+    defer span.End()
+
+    timer := prometheus.NewTimer(queryDuration.WithLabelValues("batch_create"))
+    defer timer.ObserveDuration()
+
+    docs := make([]interface{}, len(tags))
+    for i, tag := range tags {
+        cells, err := r.index.CellIDs(tag.Location)
+        if err != nil {
+            tagOperations.WithLabelValues("batch_create", "failure").Inc()
+            return nil, fmt.Errorf("failed to compute H3 cells: %w", err)
+        }
+        tag.Location.H3Cells = cells
+        docs[i] = tag
+    }
+
+    res, err := r.collection.InsertMany(ctx, docs)
+    if err != nil {
+        tagOperations.WithLabelValues("batch_create", "failure").Inc()
+        if mongo.IsDuplicateKeyError(err) {
+            return nil, ErrDuplicateTag
+        }
+        return nil, fmt.Errorf("failed to batch create tags: %w", err)
+    }
+
+    for i, insertedID := range res.InsertedIDs {
+        tags[i].ID = insertedID.(primitive.ObjectID)
+    }
+
+    tagOperations.WithLabelValues("batch_create", "success").Add(float64(len(tags)))
+    return tags, nil
+}
+
+// CreateImmutableRule persists a new immutable-tag rule.
+func (r *MongoRepository) CreateImmutableRule(ctx context.Context, rule *models.ImmutableRule) (*models.ImmutableRule, error) {
+    res, err := r.rulesCollection.InsertOne(ctx, rule)
+    if err != nil {
+        tagOperations.WithLabelValues("create_immutable_rule", "failure").Inc()
+        return nil, fmt.Errorf("failed to create immutable rule: %w", err)
+    }
+
+    rule.ID = res.InsertedID.(primitive.ObjectID)
+    tagOperations.WithLabelValues("create_immutable_rule", "success").Inc()
+    return rule, nil
+}
+
+// ActiveImmutableRules returns every enabled immutable rule, with its
+// content pattern compiled, for policy.RuleEvaluator to check tags against.
+func (r *MongoRepository) ActiveImmutableRules(ctx context.Context) ([]*models.ImmutableRule, error) {
+    cursor, err := r.rulesCollection.Find(ctx, bson.M{"enabled": true})
+    if err != nil {
+        return nil, fmt.Errorf("failed to query immutable rules: %w", err)
+    }
+    defer cursor.Close(ctx)
+
+    var rules []*models.ImmutableRule
+    if err := cursor.All(ctx, &rules); err != nil {
+        return nil, fmt.Errorf("failed to decode immutable rules: %w", err)
+    }
+
+    for _, rule := range rules {
+        if err := rule.Compile(); err != nil {
+            return nil, fmt.Errorf("invalid immutable rule %s: %w", rule.ID.Hex(), err)
+        }
+    }
+    return rules, nil
+}
+
+// Enqueue persists a failed neighbor-replication attempt so it survives a
+// process restart; it implements replication.Outbox. Draining the outbox
+// back out to retry is a separate process's job, not this repository's.
+func (r *MongoRepository) Enqueue(ctx context.Context, entry replication.OutboxEntry) error {
+    if _, err := r.outboxCollection.InsertOne(ctx, entry); err != nil {
+        tagOperations.WithLabelValues("enqueue_replication_retry", "failure").Inc()
+        return fmt.Errorf("failed to enqueue replication retry: %w", err)
+    }
+    tagOperations.WithLabelValues("enqueue_replication_retry", "success").Inc()
+    return nil
+}
+
+// CreateRetentionRule persists a new retention sweep rule.
+func (r *MongoRepository) CreateRetentionRule(ctx context.Context, rule *retention.Rule) (*retention.Rule, error) {
+    res, err := r.retentionRulesCollection.InsertOne(ctx, rule)
+    if err != nil {
+        tagOperations.WithLabelValues("create_retention_rule", "failure").Inc()
+        return nil, fmt.Errorf("failed to create retention rule: %w", err)
+    }
+
+    rule.ID = res.InsertedID.(primitive.ObjectID)
+    tagOperations.WithLabelValues("create_retention_rule", "success").Inc()
+    return rule, nil
+}
+
+// RetentionRule fetches a single retention rule by ID, for
+// service.RetentionService.DryRun to evaluate ahead of enabling it.
+func (r *MongoRepository) RetentionRule(ctx context.Context, id primitive.ObjectID) (*retention.Rule, error) {
+    var rule retention.Rule
+    if err := r.retentionRulesCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&rule); err != nil {
+        tagOperations.WithLabelValues("get_retention_rule", "failure").Inc()
+        return nil, fmt.Errorf("failed to get retention rule: %w", err)
+    }
+
+    tagOperations.WithLabelValues("get_retention_rule", "success").Inc()
+    return &rule, nil
+}
+
+// ActiveRetentionRules returns every enabled retention rule, for
+// service.RetentionService.Sweep to evaluate each periodic pass.
+func (r *MongoRepository) ActiveRetentionRules(ctx context.Context) ([]*retention.Rule, error) {
+    cursor, err := r.retentionRulesCollection.Find(ctx, bson.M{"enabled": true})
+    if err != nil {
+        return nil, fmt.Errorf("failed to query retention rules: %w", err)
+    }
+    defer cursor.Close(ctx)
+
+    var rules []*retention.Rule
+    if err := cursor.All(ctx, &rules); err != nil {
+        return nil, fmt.Errorf("failed to decode retention rules: %w", err)
+    }
+    return rules, nil
+}
+
+// TagsInScope loads every tag a retention.Rule's Scope selects, for
+// retention.Candidates to evaluate against - an empty scope loads every
+// tag in the collection.
+func (r *MongoRepository) TagsInScope(ctx context.Context, scope models.RuleScope) ([]*models.Tag, error) {
+    filter := bson.M{}
+    if scope.GeohashPrefix != "" {
+        filter["location.geohash"] = bson.M{"$regex": "^" + regexp.QuoteMeta(scope.GeohashPrefix)}
+    }
+    if len(scope.Categories) > 0 {
+        filter["category"] = bson.M{"$in": scope.Categories}
+    }
+    if scope.CreatorStatusLevel != "" {
+        filter["creator_status_level"] = scope.CreatorStatusLevel
+    }
+
+    cursor, err := r.collection.Find(ctx, filter, options.Find().SetBatchSize(batchSize))
+    if err != nil {
+        tagOperations.WithLabelValues("tags_in_scope", "failure").Inc()
+        return nil, fmt.Errorf("failed to query tags in scope: %w", err)
+    }
+    defer cursor.Close(ctx)
+
+    var tags []*models.Tag
+    if err := cursor.All(ctx, &tags); err != nil {
+        tagOperations.WithLabelValues("tags_in_scope", "failure").Inc()
+        return nil, fmt.Errorf("failed to decode tags in scope: %w", err)
+    }
+
+    tagOperations.WithLabelValues("tags_in_scope", "success").Inc()
+    return tags, nil
+}
+
+// BatchDeleteTags deletes every tag in ids and returns how many documents
+// were actually removed. Callers driving a large retention sweep are
+// expected to chunk ids themselves (see service.RetentionService.Sweep)
+// rather than pass an unbounded slice in one call.
+func (r *MongoRepository) BatchDeleteTags(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+    if len(ids) == 0 {
+        return 0, nil
+    }
+
+    res, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+    if err != nil {
+        tagOperations.WithLabelValues("batch_delete_tags", "failure").Inc()
+        return 0, fmt.Errorf("failed to batch delete tags: %w", err)
+    }
+
+    tagOperations.WithLabelValues("batch_delete_tags", "success").Add(float64(res.DeletedCount))
+    return res.DeletedCount, nil
+}
@@ -0,0 +1,122 @@
+// Package retention implements declarative tag retention/GC rules: each
+// Rule selects the subset of an already-loaded tag set that a sweep should
+// remove, via one of three strategies - delete anything past MaxAge, keep
+// only the newest KeepLast tags per creator per geohash cell, and keep only
+// the single newest tag within Radius of a Hotspot. Loading the candidate
+// set and driving the actual deletes is service.RetentionService's job, so
+// Candidates can be unit tested without a live MongoDB.
+package retention
+
+import (
+    "sort"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson/primitive" // v1.11.0
+
+    "../models"
+)
+
+// Strategy selects which of Rule's fields Candidates evaluates.
+type Strategy string
+
+const (
+    // StrategyMaxAge matches tags whose ExpiresAt is older than MaxAge.
+    StrategyMaxAge Strategy = "max_age"
+    // StrategyKeepLastPerCell matches every tag beyond the KeepLast newest,
+    // grouped by (CreatorID, geohash cell).
+    StrategyKeepLastPerCell Strategy = "keep_last_per_creator_cell"
+    // StrategyKeepNewestInRadius matches every tag but the single newest
+    // one within Radius meters of Hotspot.
+    StrategyKeepNewestInRadius Strategy = "keep_newest_in_radius"
+)
+
+// Rule declares one retention sweep policy.
+type Rule struct {
+    ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+    Name     string             `bson:"name" json:"name"`
+    Strategy Strategy           `bson:"strategy" json:"strategy"`
+
+    // Scope narrows the tags service.RetentionService loads before
+    // Candidates is evaluated; an empty field matches everything.
+    Scope models.RuleScope `bson:"scope" json:"scope"`
+
+    // MaxAge is used by StrategyMaxAge.
+    MaxAge time.Duration `bson:"max_age,omitempty" json:"max_age,omitempty"`
+
+    // KeepLast is used by StrategyKeepLastPerCell.
+    KeepLast int `bson:"keep_last,omitempty" json:"keep_last,omitempty"`
+
+    // Hotspot and Radius are used by StrategyKeepNewestInRadius.
+    Hotspot models.Location `bson:"hotspot,omitempty" json:"hotspot,omitempty"`
+    Radius  float64         `bson:"radius,omitempty" json:"radius,omitempty"`
+
+    Enabled bool `bson:"enabled" json:"enabled"`
+}
+
+// geohashCellLength is the geohash prefix length StrategyKeepLastPerCell
+// groups tags by - coarser than a tag's full geohash so "per geohash cell"
+// means a meaningful area rather than a near-unique point.
+const geohashCellLength = 7
+
+// Candidates returns the subset of tags rule would delete. now is the time
+// a sweep or DryRun call is evaluated at, so age-based strategies are
+// deterministic for a given call rather than depending on time.Now
+// internally.
+func Candidates(rule Rule, tags []*models.Tag, now time.Time) []*models.Tag {
+    switch rule.Strategy {
+    case StrategyMaxAge:
+        return candidatesByMaxAge(rule, tags, now)
+    case StrategyKeepLastPerCell:
+        return candidatesByKeepLastPerCell(rule, tags)
+    case StrategyKeepNewestInRadius:
+        return candidatesByKeepNewestInRadius(rule, tags)
+    default:
+        return nil
+    }
+}
+
+func candidatesByMaxAge(rule Rule, tags []*models.Tag, now time.Time) []*models.Tag {
+    var candidates []*models.Tag
+    for _, tag := range tags {
+        if now.Sub(tag.ExpiresAt) > rule.MaxAge {
+            candidates = append(candidates, tag)
+        }
+    }
+    return candidates
+}
+
+func candidatesByKeepLastPerCell(rule Rule, tags []*models.Tag) []*models.Tag {
+    groups := make(map[string][]*models.Tag)
+    for _, tag := range tags {
+        cell := tag.Location.Geohash
+        if len(cell) > geohashCellLength {
+            cell = cell[:geohashCellLength]
+        }
+        key := tag.CreatorID + "|" + cell
+        groups[key] = append(groups[key], tag)
+    }
+
+    var candidates []*models.Tag
+    for _, group := range groups {
+        sort.Slice(group, func(i, j int) bool { return group[i].CreatedAt.After(group[j].CreatedAt) })
+        if len(group) > rule.KeepLast {
+            candidates = append(candidates, group[rule.KeepLast:]...)
+        }
+    }
+    return candidates
+}
+
+func candidatesByKeepNewestInRadius(rule Rule, tags []*models.Tag) []*models.Tag {
+    var inRadius []*models.Tag
+    for _, tag := range tags {
+        if rule.Hotspot.DistanceTo(tag.Location) <= rule.Radius {
+            inRadius = append(inRadius, tag)
+        }
+    }
+    if len(inRadius) <= 1 {
+        return nil
+    }
+
+    sort.Slice(inRadius, func(i, j int) bool { return inRadius[i].CreatedAt.After(inRadius[j].CreatedAt) })
+    return inRadius[1:]
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "net"
+    "net/http"
+
+    "github.com/grpc-ecosystem/grpc-gateway/v2/runtime" // v2.15.0
+    "github.com/rs/cors" // v1.9.0
+    "github.com/tmc/grpc-websocket-proxy/wsproxy" // v0.0.0
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials"
+
+    "internal/config"
+    "internal/service"
+    pb "pkg/proto"
+)
+
+// Gateway bridges REST and WebSocket clients onto the gRPC server. REST
+// routes are translated by grpc-gateway straight into the matching unary
+// RPC; StreamTagUpdates is wrapped with grpc-websocket-proxy instead, since
+// grpc-gateway itself has no server-streaming-over-WebSocket support. It
+// also mounts /metrics, the only HTTP listener this service runs.
+type Gateway struct {
+    httpServer *http.Server
+}
+
+// NewGateway dials grpcEndpoint and builds the REST/WebSocket mux described
+// above. creds is nil for a plaintext gRPC backend, or the same credentials
+// the gRPC server was configured with when GRPC.EnableTLS is set.
+func NewGateway(ctx context.Context, cfg *config.HTTPConfig, grpcEndpoint string, creds credentials.TransportCredentials) (*Gateway, error) {
+    var dialOpts []grpc.DialOption
+    if creds != nil {
+        dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+    } else {
+        dialOpts = append(dialOpts, grpc.WithInsecure())
+    }
+
+    mux := runtime.NewServeMux()
+    if err := pb.RegisterTagServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, dialOpts); err != nil {
+        return nil, fmt.Errorf("failed to register gateway handlers: %w", err)
+    }
+
+    // StreamTagUpdates is mapped to a streaming GET /v1/tags/stream; wsproxy
+    // intercepts the WebSocket upgrade on that path and replays it against
+    // mux as that streaming grpc-gateway call, so both paths share the same
+    // underlying dial.
+    wsHandler := wsproxy.WebsocketProxy(mux,
+        wsproxy.WithMaxRespBodyBufferSize(cfg.WebsocketMaxMessageBytes),
+    )
+
+    topMux := http.NewServeMux()
+    topMux.Handle("/v1/tags/stream", wsHandler)
+    topMux.Handle("/metrics", service.NewMetricsHandler())
+    topMux.Handle("/", mux)
+
+    handler := cors.New(cors.Options{
+        AllowedOrigins: cfg.CORSOrigins,
+        AllowedMethods: []string{http.MethodGet, http.MethodPost},
+    }).Handler(topMux)
+
+    return &Gateway{
+        httpServer: &http.Server{
+            Addr:    fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+            Handler: handler,
+        },
+    }, nil
+}
+
+// Serve starts the HTTP listener and blocks until it stops.
+func (g *Gateway) Serve() error {
+    lis, err := net.Listen("tcp", g.httpServer.Addr)
+    if err != nil {
+        return fmt.Errorf("failed to listen on %s: %w", g.httpServer.Addr, err)
+    }
+    if err := g.httpServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+        return err
+    }
+    return nil
+}
+
+// Shutdown gracefully stops the HTTP listener, letting in-flight REST calls
+// and WebSocket streams drain up to ctx's deadline.
+func (g *Gateway) Shutdown(ctx context.Context) error {
+    return g.httpServer.Shutdown(ctx)
+}
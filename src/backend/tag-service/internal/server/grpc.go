@@ -3,20 +3,33 @@ package server
 import (
     "context"
     "fmt"
+    "log/slog"
     "net"
+    "os"
+    "sync"
     "time"
 
-    grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware" // v2.0.0
+    "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc" // v0.36.0
     grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"  // v1.2.0
     "github.com/prometheus/client_golang/prometheus" // v1.11.0
+    "go.opentelemetry.io/otel" // v1.11.0
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc" // v1.11.0
+    "go.opentelemetry.io/otel/propagation" // v1.11.0
+    "go.opentelemetry.io/otel/sdk/resource" // v1.11.0
+    sdktrace "go.opentelemetry.io/otel/sdk/trace" // v1.11.0
+    semconv "go.opentelemetry.io/otel/semconv/v1.17.0" // v1.11.0
     "google.golang.org/grpc" // v1.45.0
     "google.golang.org/grpc/codes" // v1.1.0
     "google.golang.org/grpc/credentials" // v1.45.0
     "google.golang.org/grpc/health/grpc_health_v1" // v1.45.0
     "google.golang.org/grpc/keepalive" // v1.45.0
+    "google.golang.org/grpc/peer" // v1.45.0
     "google.golang.org/grpc/status" // v1.1.0
 
     "internal/config"
+    "internal/health"
+    "internal/logging"
+    "internal/repository"
     "internal/service"
     pb "pkg/proto"
 )
@@ -41,23 +54,47 @@ var (
     )
 )
 
-// Server represents the gRPC server for tag service
+// Server represents the gRPC server for tag service. It is constructed and
+// starts listening before MongoDB is necessarily reachable; CreateTag,
+// GetNearbyTags and StreamTagUpdates return Unavailable until MarkReady is
+// called, which the bootstrap actor group does once its Mongo connect actor
+// finishes connecting, ensuring indexes, and building the TagService.
 type Server struct {
     pb.UnimplementedTagServiceServer
+    config         *config.Config
+    server         *grpc.Server
+    gateway        *Gateway
+    health         *health.Reporter
+    tracerProvider *sdktrace.TracerProvider
+    Logger         *slog.Logger
+
+    readyMu    sync.RWMutex
     tagService *service.TagService
-    config     *config.Config
-    server     *grpc.Server
+    repo       *repository.MongoRepository
+    readyCh    chan struct{}
+    readyOnce  sync.Once
 }
 
-// NewServer creates a new gRPC server instance with all middleware and configuration
-func NewServer(tagService *service.TagService, cfg *config.Config) (*Server, error) {
-    if tagService == nil {
-        return nil, fmt.Errorf("tag service is required")
+// NewServer creates a new gRPC server instance with all middleware and
+// configuration, but with no TagService wired in yet - see MarkReady. If
+// logger is nil, a default JSON logger writing to stderr is used.
+func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
+    if logger == nil {
+        logger = logging.NewJSONLogger(os.Stderr, slog.LevelInfo)
     }
 
     // Register metrics
     prometheus.MustRegister(grpcRequestDuration, grpcRequestTotal)
 
+    tp, err := initTracerProvider(context.Background(), &cfg.OTLP)
+    if err != nil {
+        return nil, fmt.Errorf("failed to initialize tracer provider: %w", err)
+    }
+    otel.SetTracerProvider(tp)
+    otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+        propagation.TraceContext{}, propagation.Baggage{},
+    ))
+
     // Configure server options
     opts := []grpc.ServerOption{
         grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -71,17 +108,19 @@ func NewServer(tagService *service.TagService, cfg *config.Config) (*Server, err
             PermitWithoutStream: true,
         }),
         grpc.MaxConcurrentStreams(1000),
+        grpc.StatsHandler(otelgrpc.NewServerHandler()),
         grpc.ChainUnaryInterceptor(
             grpc_prometheus.UnaryServerInterceptor,
-            unaryServerInterceptor(),
+            loggingUnaryInterceptor(logger),
         ),
         grpc.ChainStreamInterceptor(
             grpc_prometheus.StreamServerInterceptor,
-            streamServerInterceptor(),
+            loggingStreamInterceptor(logger),
         ),
     }
 
     // Configure TLS if enabled
+    var gatewayCreds credentials.TransportCredentials
     if cfg.GRPC.EnableTLS {
         creds, err := credentials.NewServerTLSFromFile(
             cfg.GRPC.CertFile,
@@ -91,16 +130,33 @@ func NewServer(tagService *service.TagService, cfg *config.Config) (*Server, err
             return nil, fmt.Errorf("failed to load TLS credentials: %v", err)
         }
         opts = append(opts, grpc.Creds(creds))
+
+        // The gateway dials back in as a client of this same server, so it
+        // needs client-side credentials trusting the same certificate.
+        gatewayCreds, err = credentials.NewClientTLSFromFile(cfg.GRPC.CertFile, "")
+        if err != nil {
+            return nil, fmt.Errorf("failed to load gateway TLS credentials: %v", err)
+        }
     }
 
     // Create gRPC server
     grpcServer := grpc.NewServer(opts...)
 
+    grpcEndpoint := fmt.Sprintf("%s:%d", cfg.GRPC.Host, cfg.GRPC.Port)
+    gateway, err := NewGateway(context.Background(), &cfg.HTTP, grpcEndpoint, gatewayCreds)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create gateway: %w", err)
+    }
+
     // Create server instance
     server := &Server{
-        tagService: tagService,
-        config:     cfg,
-        server:     grpcServer,
+        config:         cfg,
+        server:         grpcServer,
+        gateway:        gateway,
+        health:         health.NewReporter(cfg.Health.ProbeInterval, logger),
+        tracerProvider: tp,
+        Logger:         logger,
+        readyCh:        make(chan struct{}),
     }
 
     // Register services
@@ -111,6 +167,76 @@ func NewServer(tagService *service.TagService, cfg *config.Config) (*Server, err
     return server, nil
 }
 
+// initTracerProvider builds a TracerProvider that batches spans to an OTLP
+// gRPC collector at cfg.Endpoint, sampling at cfg.SampleRatio.
+func initTracerProvider(ctx context.Context, cfg *config.OTLPConfig) (*sdktrace.TracerProvider, error) {
+    var exporterOpts []otlptracegrpc.Option
+    exporterOpts = append(exporterOpts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+    if cfg.Insecure {
+        exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+    }
+
+    exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+    }
+
+    res, err := resource.New(ctx, resource.WithAttributes(
+        semconv.ServiceNameKey.String(cfg.ServiceName),
+    ))
+    if err != nil {
+        return nil, fmt.Errorf("failed to create OTel resource: %w", err)
+    }
+
+    return sdktrace.NewTracerProvider(
+        sdktrace.WithBatcher(exporter),
+        sdktrace.WithResource(res),
+        sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+    ), nil
+}
+
+// MarkReady wires tagService and repo into the server and the health
+// reporter, and closes the channel Ready returns. Called once, by the
+// bootstrap actor group's MongoDB connect actor; safe to call concurrently
+// with in-flight RPCs and probing.
+func (s *Server) MarkReady(tagService *service.TagService, repo *repository.MongoRepository) {
+    s.readyMu.Lock()
+    s.tagService = tagService
+    s.repo = repo
+    s.readyMu.Unlock()
+
+    s.health.SetDependencies(repo, tagService)
+    s.readyOnce.Do(func() { close(s.readyCh) })
+}
+
+// Ready returns a channel that closes once MarkReady has been called.
+func (s *Server) Ready() <-chan struct{} {
+    return s.readyCh
+}
+
+// isReady reports whether MarkReady has run, and returns the TagService it
+// wired in if so.
+func (s *Server) isReady() (*service.TagService, bool) {
+    s.readyMu.RLock()
+    defer s.readyMu.RUnlock()
+    return s.tagService, s.tagService != nil
+}
+
+// Repository returns the MongoRepository wired in by MarkReady, or nil
+// before that has happened. Callers that only run after Ready() is closed
+// (the bootstrap cleanup-ticker actor) can rely on this being non-nil.
+func (s *Server) Repository() *repository.MongoRepository {
+    s.readyMu.RLock()
+    defer s.readyMu.RUnlock()
+    return s.repo
+}
+
+// HealthReporter returns the server's health.Reporter, so the bootstrap actor
+// group can run its probing loop as its own actor.
+func (s *Server) HealthReporter() *health.Reporter {
+    return s.health
+}
+
 // Start starts the gRPC server
 func (s *Server) Start(ctx context.Context) error {
     addr := fmt.Sprintf("%s:%d", s.config.GRPC.Host, s.config.GRPC.Port)
@@ -119,9 +245,27 @@ func (s *Server) Start(ctx context.Context) error {
         return fmt.Errorf("failed to listen: %v", err)
     }
 
+    go func() {
+        if err := s.gateway.Serve(); err != nil {
+            s.Logger.Error("gateway server stopped unexpectedly", "error", err)
+        }
+    }()
+
     go func() {
         <-ctx.Done()
         s.server.GracefulStop()
+
+        shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+        defer cancel()
+        if err := s.gateway.Shutdown(shutdownCtx); err != nil {
+            s.Logger.Error("failed to shut down gateway", "error", err)
+        }
+        // Flush any spans still buffered in the batch processor before the
+        // process exits, so a request that completed just before shutdown
+        // isn't silently dropped from the trace backend.
+        if err := s.tracerProvider.Shutdown(shutdownCtx); err != nil {
+            fmt.Printf("failed to shut down tracer provider: %v\n", err)
+        }
     }()
 
     return s.server.Serve(lis)
@@ -132,12 +276,18 @@ func (s *Server) CreateTag(ctx context.Context, req *pb.CreateTagRequest) (*pb.T
     timer := prometheus.NewTimer(grpcRequestDuration.WithLabelValues("CreateTag", ""))
     defer timer.ObserveDuration()
 
+    tagService, ready := s.isReady()
+    if !ready {
+        grpcRequestTotal.WithLabelValues("CreateTag", "unavailable").Inc()
+        return nil, status.Error(codes.Unavailable, "tag service is starting up")
+    }
+
     if err := validateCreateTagRequest(req); err != nil {
         grpcRequestTotal.WithLabelValues("CreateTag", "invalid").Inc()
         return nil, status.Error(codes.InvalidArgument, err.Error())
     }
 
-    tag, err := s.tagService.CreateTag(ctx, convertToModelTag(req))
+    tag, err := tagService.CreateTag(ctx, convertToModelTag(req))
     if err != nil {
         grpcRequestTotal.WithLabelValues("CreateTag", "error").Inc()
         return nil, status.Error(codes.Internal, "failed to create tag")
@@ -152,12 +302,18 @@ func (s *Server) GetNearbyTags(ctx context.Context, req *pb.GetNearbyTagsRequest
     timer := prometheus.NewTimer(grpcRequestDuration.WithLabelValues("GetNearbyTags", ""))
     defer timer.ObserveDuration()
 
+    tagService, ready := s.isReady()
+    if !ready {
+        grpcRequestTotal.WithLabelValues("GetNearbyTags", "unavailable").Inc()
+        return nil, status.Error(codes.Unavailable, "tag service is starting up")
+    }
+
     if err := validateGetNearbyTagsRequest(req); err != nil {
         grpcRequestTotal.WithLabelValues("GetNearbyTags", "invalid").Inc()
         return nil, status.Error(codes.InvalidArgument, err.Error())
     }
 
-    tags, err := s.tagService.GetNearbyTags(ctx, convertToModelLocation(req.Location), req.RadiusMeters, req.UserId)
+    tags, err := tagService.GetNearbyTags(ctx, convertToModelLocation(req.Location), req.RadiusMeters, req.UserId)
     if err != nil {
         grpcRequestTotal.WithLabelValues("GetNearbyTags", "error").Inc()
         return nil, status.Error(codes.Internal, "failed to get nearby tags")
@@ -171,24 +327,43 @@ func (s *Server) GetNearbyTags(ctx context.Context, req *pb.GetNearbyTagsRequest
     }, nil
 }
 
-// StreamTagUpdates implements the StreamTagUpdates RPC method
+// StreamTagUpdates implements the StreamTagUpdates RPC method, wrapping
+// TagService.Subscribe as a gRPC server stream. The client's viewport is
+// fixed for the lifetime of the call; a client that moves is expected to
+// cancel and reconnect with its new location, which tears down the old
+// Subscribe goroutine and starts a fresh one with the new covering prefixes.
 func (s *Server) StreamTagUpdates(req *pb.StreamTagUpdatesRequest, stream pb.TagService_StreamTagUpdatesServer) error {
     timer := prometheus.NewTimer(grpcRequestDuration.WithLabelValues("StreamTagUpdates", ""))
     defer timer.ObserveDuration()
 
+    tagService, ready := s.isReady()
+    if !ready {
+        grpcRequestTotal.WithLabelValues("StreamTagUpdates", "unavailable").Inc()
+        return status.Error(codes.Unavailable, "tag service is starting up")
+    }
+
     if err := validateStreamTagUpdatesRequest(req); err != nil {
         grpcRequestTotal.WithLabelValues("StreamTagUpdates", "invalid").Inc()
         return status.Error(codes.InvalidArgument, err.Error())
     }
 
-    updates := s.tagService.SubscribeToUpdates(stream.Context(), req.Location, req.RadiusMeters)
+    updates, err := tagService.Subscribe(stream.Context(), convertToModelLocation(req.Location), req.RadiusMeters, req.UserId)
+    if err != nil {
+        grpcRequestTotal.WithLabelValues("StreamTagUpdates", "error").Inc()
+        return status.Error(codes.Internal, "failed to subscribe to tag updates")
+    }
+
     for {
         select {
         case <-stream.Context().Done():
             grpcRequestTotal.WithLabelValues("StreamTagUpdates", "cancelled").Inc()
             return status.Error(codes.Canceled, "stream cancelled by client")
-        case update := <-updates:
-            if err := stream.Send(convertToProtoTag(update)); err != nil {
+        case event, ok := <-updates:
+            if !ok {
+                grpcRequestTotal.WithLabelValues("StreamTagUpdates", "closed").Inc()
+                return nil
+            }
+            if err := stream.Send(convertToProtoTag(event.Tag)); err != nil {
                 grpcRequestTotal.WithLabelValues("StreamTagUpdates", "error").Inc()
                 return status.Error(codes.Internal, "failed to send update")
             }
@@ -197,16 +372,75 @@ func (s *Server) StreamTagUpdates(req *pb.StreamTagUpdatesRequest, stream pb.Tag
     }
 }
 
-// Check implements the health checking service
+// Check implements the health checking service, consulting the health
+// reporter's latest dependency probe rather than always returning SERVING.
 func (s *Server) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
     return &grpc_health_v1.HealthCheckResponse{
-        Status: grpc_health_v1.HealthCheckResponse_SERVING,
+        Status: s.health.Check(req.Service),
     }, nil
 }
 
-// Watch implements the health checking service streaming method
+// Watch implements the health checking service streaming method, sending the
+// current status immediately and then every subsequent change the reporter
+// observes, until the client cancels or the reporter's channel is closed by
+// Reporter.Stop during server shutdown.
 func (s *Server) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
-    return status.Error(codes.Unimplemented, "health check watching not implemented")
+    ch, cancel := s.health.Watch(req.Service)
+    defer cancel()
+
+    for {
+        select {
+        case <-stream.Context().Done():
+            return status.Error(codes.Canceled, "watch cancelled by client")
+        case st, ok := <-ch:
+            if !ok {
+                return nil
+            }
+            if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: st}); err != nil {
+                return err
+            }
+        }
+    }
+}
+
+// loggingUnaryInterceptor logs the outcome of every unary RPC: method,
+// resulting status code, duration, and peer address.
+func loggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+    return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+        start := time.Now()
+        resp, err := handler(ctx, req)
+
+        logger.InfoContext(ctx, "grpc request completed",
+            "method", info.FullMethod,
+            "status", status.Code(err).String(),
+            "duration_ms", time.Since(start).Milliseconds(),
+            "peer", peerAddr(ctx))
+        return resp, err
+    }
+}
+
+// loggingStreamInterceptor logs the outcome of every streaming RPC once the
+// stream closes: method, resulting status code, duration, and peer address.
+func loggingStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+    return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+        start := time.Now()
+        err := handler(srv, ss)
+
+        logger.InfoContext(ss.Context(), "grpc stream completed",
+            "method", info.FullMethod,
+            "status", status.Code(err).String(),
+            "duration_ms", time.Since(start).Milliseconds(),
+            "peer", peerAddr(ss.Context()))
+        return err
+    }
+}
+
+// peerAddr returns the caller's address from ctx, or "unknown" if none is set.
+func peerAddr(ctx context.Context) string {
+    if p, ok := peer.FromContext(ctx); ok {
+        return p.Addr.String()
+    }
+    return "unknown"
 }
 
 // Helper functions for request validation and conversion are implemented here...
\ No newline at end of file
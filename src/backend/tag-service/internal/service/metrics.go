@@ -0,0 +1,14 @@
+package service
+
+import (
+    "net/http"
+
+    "github.com/prometheus/client_golang/prometheus/promhttp" // v1.16.0
+)
+
+// NewMetricsHandler returns the HTTP handler that exposes the default
+// Prometheus registry, including the tag service's operation, cache and
+// nearby-result-size collectors, for scraping at /metrics.
+func NewMetricsHandler() http.Handler {
+    return promhttp.Handler()
+}
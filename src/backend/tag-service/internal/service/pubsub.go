@@ -0,0 +1,163 @@
+package service
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "github.com/go-redis/redis/v8" // v8.11.5
+    "github.com/mmcloughlin/geohash" // v0.10.0
+    "github.com/prometheus/client_golang/prometheus" // v1.16.0
+
+    "../models"
+)
+
+// geohashPrefixLength is the granularity tag events are published at. Coarser
+// than the full geohash stored on a tag so a handful of PSUBSCRIBE patterns
+// cover a viewport, at the cost of some false-positive deliveries the caller
+// filters by radius.
+const geohashPrefixLength = 4
+
+// subscriberBufferSize bounds the channel returned by Subscribe. Once full,
+// the oldest queued event is dropped to make room for the newest one, so a
+// slow consumer degrades to "latest state" rather than blocking publishers.
+const subscriberBufferSize = 256
+
+const tagEventChannelPrefix = "tag_events:"
+
+// TagEventType identifies the kind of mutation a TagEvent carries.
+type TagEventType string
+
+// Tag event kinds published by CreateTag, UpdateTag and DeleteTag.
+const (
+    TagEventCreated TagEventType = "created"
+    TagEventUpdated TagEventType = "updated"
+    TagEventDeleted TagEventType = "deleted"
+)
+
+// TagEvent is the payload published on a tag_events:<geohash-prefix> channel
+// and delivered to Subscribe callers whose viewport overlaps that prefix.
+type TagEvent struct {
+    Type      TagEventType `json:"type"`
+    Tag       *models.Tag  `json:"tag"`
+    Timestamp time.Time    `json:"timestamp"`
+}
+
+var subscriberDroppedEventsTotal = prometheus.NewCounterVec(
+    prometheus.CounterOpts{
+        Name: "tag_service_subscriber_dropped_events_total",
+        Help: "Total number of tag events dropped because a subscriber's channel was full",
+    },
+    []string{"geohash_prefix"},
+)
+
+func tagEventChannel(geohashPrefix string) string {
+    return tagEventChannelPrefix + geohashPrefix
+}
+
+// publishTagEvent notifies subscribers of a tag mutation. Publish failures
+// are logged and counted but never fail the calling CRUD operation - a
+// missed real-time update is recoverable via the next GetNearbyTags poll.
+func (s *TagService) publishTagEvent(ctx context.Context, eventType TagEventType, tag *models.Tag) {
+    prefix := tag.Location.Geohash
+    if len(prefix) > geohashPrefixLength {
+        prefix = prefix[:geohashPrefixLength]
+    }
+
+    payload, err := json.Marshal(TagEvent{Type: eventType, Tag: tag, Timestamp: time.Now()})
+    if err != nil {
+        s.Logger.WarnContext(ctx, "failed to marshal tag event",
+            "operation", "publish_tag_event", "tag_id", tag.ID.Hex(), "error", err)
+        return
+    }
+
+    if err := s.cache.Publish(ctx, tagEventChannel(prefix), payload).Err(); err != nil {
+        s.cacheOperations.WithLabelValues("publish", "failed").Inc()
+        s.Logger.WarnContext(ctx, "failed to publish tag event",
+            "operation", "publish_tag_event", "tag_id", tag.ID.Hex(), "error", err)
+        return
+    }
+    s.cacheOperations.WithLabelValues("publish", "success").Inc()
+}
+
+// coveringGeohashPrefixes returns the geohashPrefixLength-length prefixes
+// whose cells overlap a circle of radiusMeters around location: the cell the
+// point falls in plus its 8 neighbours. This over-covers rather than
+// under-covers so a viewport near a cell boundary still sees every event.
+func coveringGeohashPrefixes(location models.Location, radiusMeters float64) []string {
+    center := geohash.EncodeWithPrecision(location.Latitude, location.Longitude, uint(geohashPrefixLength))
+    neighbors := geohash.Neighbors(center)
+    return append(neighbors, center)
+}
+
+// Subscribe streams create/update/delete events for tags whose geohash
+// prefix falls inside the caller's viewport. The returned channel is closed
+// when ctx is cancelled. Callers whose location moves enough to change the
+// covering prefixes (e.g. the gRPC StreamTagUpdates wrapper) should cancel
+// and call Subscribe again rather than mutate an existing subscription.
+func (s *TagService) Subscribe(ctx context.Context, location models.Location, radius float64, userStatusLevel string) (<-chan TagEvent, error) {
+    if err := location.Validate(); err != nil {
+        return nil, err
+    }
+
+    prefixes := coveringGeohashPrefixes(location, radius)
+    patterns := make([]string, 0, len(prefixes))
+    for _, prefix := range prefixes {
+        patterns = append(patterns, tagEventChannel(prefix))
+    }
+
+    pubsub := s.cache.PSubscribe(ctx, patterns...)
+    events := make(chan TagEvent, subscriberBufferSize)
+
+    go s.fanOutSubscription(ctx, pubsub, events, location, radius, userStatusLevel)
+
+    return events, nil
+}
+
+// fanOutSubscription decodes published events, applies the caller's
+// visibility rules, and delivers them with drop-oldest backpressure.
+func (s *TagService) fanOutSubscription(ctx context.Context, pubsub *redis.PubSub, events chan<- TagEvent, location models.Location, radius float64, userStatusLevel string) {
+    defer close(events)
+    defer pubsub.Close()
+
+    ch := pubsub.Channel()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case msg, ok := <-ch:
+            if !ok {
+                return
+            }
+
+            var event TagEvent
+            if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+                s.Logger.WarnContext(ctx, "failed to decode tag event", "operation", "subscribe", "error", err)
+                continue
+            }
+            if event.Tag == nil || !event.Tag.IsVisible(userStatusLevel, location) {
+                continue
+            }
+
+            select {
+            case events <- event:
+            default:
+                // Consumer is behind; drop the oldest queued event to make
+                // room rather than block the fan-out goroutine.
+                select {
+                case <-events:
+                    prefix := event.Tag.Location.Geohash
+                    if len(prefix) > geohashPrefixLength {
+                        prefix = prefix[:geohashPrefixLength]
+                    }
+                    subscriberDroppedEventsTotal.WithLabelValues(prefix).Inc()
+                default:
+                }
+                select {
+                case events <- event:
+                default:
+                }
+            }
+        }
+    }
+}
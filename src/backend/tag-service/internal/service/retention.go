@@ -0,0 +1,201 @@
+package service
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+    "os"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus" // v1.16.0
+    "go.mongodb.org/mongo-driver/bson/primitive" // v1.11.0
+
+    "../logging"
+    "../models"
+    "../policy"
+    "../retention"
+)
+
+// retentionDeleteChunkSize bounds a single BatchDeleteTags call a sweep
+// issues, so a large sweep never attempts one unbounded bulk delete.
+const retentionDeleteChunkSize = 500
+
+var retentionSweepTags = prometheus.NewCounterVec(
+    prometheus.CounterOpts{
+        Name: "tag_service_retention_sweep_tags_total",
+        Help: "Total tags affected by retention sweeps, partitioned by rule name and result",
+    },
+    []string{"rule", "result"},
+)
+
+func init() { prometheus.MustRegister(retentionSweepTags) }
+
+// RetentionRepository is the subset of repository.MongoRepository
+// RetentionService needs: loading the tags a rule's Scope selects, and
+// deleting the ones a sweep decides to remove.
+type RetentionRepository interface {
+    TagsInScope(ctx context.Context, scope models.RuleScope) ([]*models.Tag, error)
+    BatchDeleteTags(ctx context.Context, ids []primitive.ObjectID) (int64, error)
+}
+
+// RetentionRuleSource is the subset of repository.MongoRepository
+// RetentionService needs to load the retention.Rule(s) a DryRun or Sweep
+// call evaluates.
+type RetentionRuleSource interface {
+    RetentionRule(ctx context.Context, id primitive.ObjectID) (*retention.Rule, error)
+    ActiveRetentionRules(ctx context.Context) ([]*retention.Rule, error)
+}
+
+// RetentionOption configures a RetentionService at construction.
+type RetentionOption func(*RetentionService)
+
+// WithRetentionPolicyEvaluator wires the immutable-tag policy guard into
+// the sweep: any candidate a rule pins stays untouched. Leaving this unset
+// (the default) means a sweep deletes every candidate a rule selects.
+func WithRetentionPolicyEvaluator(evaluator policy.ImmutablePolicyEvaluator) RetentionOption {
+    return func(s *RetentionService) {
+        s.policyEvaluator = evaluator
+    }
+}
+
+// WithRetentionLogger overrides the default stderr JSON logger.
+func WithRetentionLogger(logger *slog.Logger) RetentionOption {
+    return func(s *RetentionService) {
+        s.Logger = logger
+    }
+}
+
+// RetentionService periodically sweeps tags against declarative
+// retention.Rule documents, deleting whatever each enabled rule selects
+// (skipping anything the immutable-tag policy pins) in bounded chunks.
+type RetentionService struct {
+    repo            RetentionRepository
+    rules           RetentionRuleSource
+    policyEvaluator policy.ImmutablePolicyEvaluator
+    Logger          *slog.Logger
+}
+
+// NewRetentionService builds a RetentionService. If logger is nil (and
+// WithRetentionLogger isn't used), a default JSON logger writing to stderr
+// is used.
+func NewRetentionService(repo RetentionRepository, rules RetentionRuleSource, opts ...RetentionOption) (*RetentionService, error) {
+    if repo == nil {
+        return nil, fmt.Errorf("repository is required")
+    }
+    if rules == nil {
+        return nil, fmt.Errorf("rule source is required")
+    }
+
+    s := &RetentionService{
+        repo:   repo,
+        rules:  rules,
+        Logger: logging.NewJSONLogger(os.Stderr, slog.LevelInfo),
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s, nil
+}
+
+// DryRun loads ruleID and evaluates its candidates without deleting
+// anything, so an operator can preview what enabling the rule would remove.
+func (s *RetentionService) DryRun(ctx context.Context, ruleID primitive.ObjectID) ([]*models.Tag, error) {
+    rule, err := s.rules.RetentionRule(ctx, ruleID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load retention rule: %w", err)
+    }
+
+    tags, err := s.repo.TagsInScope(ctx, rule.Scope)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load tags in scope: %w", err)
+    }
+
+    candidates := retention.Candidates(*rule, tags, time.Now())
+    return s.skipImmutable(ctx, candidates), nil
+}
+
+// Sweep evaluates every enabled retention rule and deletes the tags each
+// one selects, in chunks of retentionDeleteChunkSize, emitting a
+// structured log event per rule swept. A single rule's failure is logged
+// and does not stop the remaining rules from being swept.
+func (s *RetentionService) Sweep(ctx context.Context) error {
+    rules, err := s.rules.ActiveRetentionRules(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to load active retention rules: %w", err)
+    }
+
+    for _, rule := range rules {
+        if err := s.sweepRule(ctx, rule); err != nil {
+            retentionSweepTags.WithLabelValues(rule.Name, "failed").Inc()
+            s.Logger.ErrorContext(ctx, "retention sweep failed",
+                "operation", "retention_sweep", "rule_id", rule.ID.Hex(), "rule_name", rule.Name, "error", err)
+        }
+    }
+    return nil
+}
+
+func (s *RetentionService) sweepRule(ctx context.Context, rule *retention.Rule) error {
+    tags, err := s.repo.TagsInScope(ctx, rule.Scope)
+    if err != nil {
+        return fmt.Errorf("failed to load tags in scope: %w", err)
+    }
+
+    candidates := s.skipImmutable(ctx, retention.Candidates(*rule, tags, time.Now()))
+    if len(candidates) == 0 {
+        s.Logger.InfoContext(ctx, "retention sweep found nothing to delete",
+            "operation", "retention_sweep", "rule_id", rule.ID.Hex(), "rule_name", rule.Name)
+        return nil
+    }
+
+    var deleted int64
+    for start := 0; start < len(candidates); start += retentionDeleteChunkSize {
+        end := start + retentionDeleteChunkSize
+        if end > len(candidates) {
+            end = len(candidates)
+        }
+
+        ids := make([]primitive.ObjectID, 0, end-start)
+        for _, tag := range candidates[start:end] {
+            ids = append(ids, tag.ID)
+        }
+
+        n, err := s.repo.BatchDeleteTags(ctx, ids)
+        if err != nil {
+            return fmt.Errorf("failed to delete retention batch: %w", err)
+        }
+        deleted += n
+    }
+
+    retentionSweepTags.WithLabelValues(rule.Name, "success").Add(float64(deleted))
+    s.Logger.InfoContext(ctx, "retention sweep complete",
+        "operation", "retention_sweep", "rule_id", rule.ID.Hex(), "rule_name", rule.Name,
+        "candidates", len(candidates), "deleted", deleted)
+    return nil
+}
+
+// skipImmutable drops every candidate the immutable-tag policy pins, if an
+// evaluator was configured via WithRetentionPolicyEvaluator; with none
+// configured every candidate passes through unchanged. A candidate that
+// can't be evaluated is kept rather than deleted, since a sweep should
+// never remove a tag it couldn't confirm is safe to remove.
+func (s *RetentionService) skipImmutable(ctx context.Context, candidates []*models.Tag) []*models.Tag {
+    if s.policyEvaluator == nil {
+        return candidates
+    }
+
+    kept := make([]*models.Tag, 0, len(candidates))
+    for _, tag := range candidates {
+        rule, err := s.policyEvaluator.Evaluate(ctx, tag)
+        if err != nil {
+            s.Logger.WarnContext(ctx, "failed to evaluate immutable policy during retention sweep, keeping tag",
+                "operation", "retention_sweep", "tag_id", tag.ID.Hex(), "error", err)
+            kept = append(kept, tag)
+            continue
+        }
+        if rule != nil {
+            continue
+        }
+        kept = append(kept, tag)
+    }
+    return kept
+}
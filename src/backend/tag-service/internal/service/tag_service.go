@@ -4,16 +4,27 @@ import (
     "context"
     "errors"
     "fmt"
+    "log/slog"
+    "os"
     "time"
 
     "github.com/go-redis/redis/v8" // v8.11.5
     "github.com/prometheus/client_golang/prometheus" // v1.16.0
     "go.mongodb.org/mongo-driver/bson/primitive" // v1.11.0
+    "go.opentelemetry.io/otel" // v1.19.0
+    "go.opentelemetry.io/otel/attribute" // v1.19.0
+    "go.opentelemetry.io/otel/metric" // v1.19.0
+    "go.opentelemetry.io/otel/trace" // v1.19.0
+    "golang.org/x/sync/singleflight" // v0.3.0
     "google.golang.org/grpc/codes" // v1.1.0
     "google.golang.org/grpc/status" // v1.1.0
 
     "../models"
-    "../repository"
+    "../logging"
+    "../filter"
+    "../policy"
+    "../replication"
+    "../signing"
 )
 
 const (
@@ -22,15 +33,29 @@ const (
     minVisibilityRadius      = 1.0
     defaultCacheTTL         = 300 // 5 minutes
     maxBatchSize            = 100
+
+    // negativeCacheTTL is deliberately shorter than defaultCacheTTL so an
+    // empty-result hotspot (e.g. a newly quiet area) doesn't stay cold for
+    // as long as a populated one.
+    negativeCacheTTL = 30
+
+    // cacheGridPrecision quantizes the lat/lon used in the GetNearbyTags
+    // cache key to this many decimal places (~1.1m at precision 5), so
+    // nearby callers share cache entries and singleflight group instead of
+    // each minting their own key.
+    cacheGridPrecision = 5
 )
 
 // Metrics collectors
 var (
+    // NativeHistogramBucketFactor turns this on as a native histogram, which
+    // is required for exemplars to be exposed on the /metrics endpoint.
     tagOperationDuration = prometheus.NewHistogramVec(
         prometheus.HistogramOpts{
-            Name: "tag_service_operation_duration_seconds",
+            Name: "tag_operation_duration_seconds",
             Help: "Duration of tag service operations",
             Buckets: prometheus.ExponentialBuckets(0.01, 2, 10),
+            NativeHistogramBucketFactor: 1.1,
         },
         []string{"operation"},
     )
@@ -42,47 +67,261 @@ var (
         },
         []string{"operation", "status"},
     )
+
+    cacheOperationsTotal = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "tag_service_cache_operations_total",
+            Help: "Total number of cache operations performed by the tag service",
+        },
+        []string{"op", "result"},
+    )
+
+    nearbyResultSize = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name: "tag_service_nearby_result_size",
+            Help: "Number of tags returned by GetNearbyTags",
+            Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+        },
+        []string{},
+    )
+
+    filterCulledTotal = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "tag_service_filter_culled_total",
+            Help: "Total number of candidate tags removed by each visibility filter",
+        },
+        []string{"filter"},
+    )
+
+    cacheSingleflightSharedTotal = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "tag_service_cache_singleflight_shared_total",
+            Help: "Total number of GetNearbyTags calls that shared a repository call coalesced by singleflight",
+        },
+        []string{},
+    )
+
+    cacheNegativeHitsTotal = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "tag_service_cache_negative_hits_total",
+            Help: "Total number of GetNearbyTags calls served from the empty-result negative cache",
+        },
+        []string{},
+    )
 )
 
-// TagService implements tag management operations with caching and monitoring
+// ErrTagNotFound is returned by UpdateTag/DeleteTag when the repository has
+// no tag on record for the given ID.
+var ErrTagNotFound = errors.New("tag not found")
+
+// Repository is the subset of the underlying MongoDB repository that
+// TagService depends on directly; repository.MongoRepository satisfies it,
+// and tests substitute a mock.
+type Repository interface {
+    CreateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+    GetNearbyTags(ctx context.Context, location models.Location, radius float64, userStatusLevel string) ([]*models.Tag, error)
+    UpdateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+    DeleteTag(ctx context.Context, id primitive.ObjectID) error
+    BatchCreateTags(ctx context.Context, tags []*models.Tag) ([]*models.Tag, error)
+    GetTagByID(ctx context.Context, id primitive.ObjectID) (*models.Tag, error)
+}
+
+// Option configures optional dependencies on a TagService, such as the
+// OpenTelemetry providers used for tracing and metrics.
+type Option func(*TagService)
+
+// WithTracerProvider overrides the tracer provider used to create spans
+// around TagService operations. Defaults to the global otel provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+    return func(s *TagService) {
+        s.tracer = tp.Tracer("spatial-tag/service")
+    }
+}
+
+// WithMeterProvider overrides the meter provider TagService takes its
+// OpenTelemetry meter from. Defaults to the global otel provider. TagService
+// records no instruments on that meter; its metrics are the Prometheus
+// collectors above.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+    return func(s *TagService) {
+        s.meter = mp.Meter("spatial-tag/service")
+    }
+}
+
+// WithFilters installs the ordered visibility/filter chain GetNearbyTags
+// applies to repository candidates, replacing the hardcoded elite check.
+// Filters run in order and a candidate is kept only if every filter matches
+// it (AllOf semantics); wrap filters in filter.AnyOf/filter.NotFilter for
+// other combinations.
+func WithFilters(filters ...filter.TagFilter) Option {
+    return func(s *TagService) {
+        s.filters = filters
+    }
+}
+
+// WithImmutablePolicyEvaluator installs the evaluator UpdateTag, DeleteTag,
+// and BatchCreateTags's replace-existing path consult before mutating a tag
+// any enabled immutable_rules entry currently pins. Leaving this unset (the
+// default) means no tag is ever treated as immutable.
+func WithImmutablePolicyEvaluator(evaluator policy.ImmutablePolicyEvaluator) Option {
+    return func(s *TagService) {
+        s.policyEvaluator = evaluator
+    }
+}
+
+// WithReplicator installs the TagReplicator CreateTag, UpdateTag, DeleteTag,
+// and BatchCreateTags hand their primary write off to once it succeeds.
+// Leaving this unset (the default) means writes are never replicated to
+// neighbor nodes.
+func WithReplicator(replicator *replication.TagReplicator) Option {
+    return func(s *TagService) {
+        s.replicator = replicator
+    }
+}
+
+// TagVerifier checks a tag's embedded creator signature on
+// create/update/batch create, and a delete's signed tombstone against the
+// tag it targets. signing.Verifier is the production implementation.
+type TagVerifier interface {
+    VerifyTag(ctx context.Context, tag *models.Tag) error
+    VerifyTombstone(ctx context.Context, current *models.Tag, tombstone signing.Tombstone) error
+}
+
+// WithTagVerifier installs the TagVerifier CreateTag, UpdateTag, and
+// BatchCreateTags consult to reject an unsigned or mis-signed tag, and
+// DeleteTag consults to require a signed tombstone before removing a tag.
+// Leaving this unset (the default) means no signature is ever required.
+func WithTagVerifier(verifier TagVerifier) Option {
+    return func(s *TagService) {
+        s.verifier = verifier
+    }
+}
+
+// TagService implements tag management operations with caching, tracing and
+// monitoring
 type TagService struct {
-    repo              *repository.MongoRepository
-    cache             *redis.Client
-    operationCounter  *prometheus.CounterVec
-    operationLatency  *prometheus.HistogramVec
+    repo                    Repository
+    cache                   *redis.Client
+    operationCounter        *prometheus.CounterVec
+    operationLatency        *prometheus.HistogramVec
+    cacheOperations         *prometheus.CounterVec
+    nearbyResultSize        *prometheus.HistogramVec
+    filterCulled            *prometheus.CounterVec
+    cacheSingleflightShared *prometheus.CounterVec
+    cacheNegativeHits       *prometheus.CounterVec
+    Logger                  *slog.Logger
+    tracer                  trace.Tracer
+    meter                   metric.Meter
+    filters                 []filter.TagFilter
+    policyEvaluator         policy.ImmutablePolicyEvaluator
+    replicator              *replication.TagReplicator
+    verifier                TagVerifier
+    nearbyGroup             singleflight.Group
 }
 
-// NewTagService creates a new TagService instance
-func NewTagService(repo *repository.MongoRepository, cache *redis.Client) (*TagService, error) {
+// NewTagService creates a new TagService instance. If logger is nil, a
+// default JSON logger writing to stderr is used so callers always get
+// structured output even if they forget to wire one in. Tracing and
+// metering default to the globally configured OpenTelemetry providers;
+// pass WithTracerProvider/WithMeterProvider to override.
+func NewTagService(repo Repository, cache *redis.Client, logger *slog.Logger, opts ...Option) (*TagService, error) {
     if repo == nil {
         return nil, errors.New("repository is required")
     }
     if cache == nil {
         return nil, errors.New("cache client is required")
     }
+    if logger == nil {
+        logger = logging.NewJSONLogger(os.Stderr, slog.LevelInfo)
+    }
 
     // Register metrics
-    prometheus.MustRegister(tagOperationDuration, tagOperationCounter)
+    prometheus.MustRegister(tagOperationDuration, tagOperationCounter, cacheOperationsTotal, nearbyResultSize,
+        subscriberDroppedEventsTotal, filterCulledTotal, cacheSingleflightSharedTotal, cacheNegativeHitsTotal)
+
+    s := &TagService{
+        repo:                    repo,
+        cache:                   cache,
+        operationCounter:        tagOperationCounter,
+        operationLatency:        tagOperationDuration,
+        cacheOperations:         cacheOperationsTotal,
+        nearbyResultSize:        nearbyResultSize,
+        filterCulled:            filterCulledTotal,
+        cacheSingleflightShared: cacheSingleflightSharedTotal,
+        cacheNegativeHits:       cacheNegativeHitsTotal,
+        Logger:                  logger,
+        tracer:                  otel.Tracer("spatial-tag/service"),
+        meter:                   otel.Meter("spatial-tag/service"),
+        filters:                 []filter.TagFilter{filter.NewStatusTierFilter()},
+    }
+
+    for _, opt := range opts {
+        opt(s)
+    }
+
+    return s, nil
+}
+
+// observeDuration records operation latency with an exemplar linking the
+// bucket back to the active span's trace ID, so a slow latency bucket in
+// Grafana can jump straight to the trace that produced it.
+func (s *TagService) observeDuration(ctx context.Context, operation string, seconds float64) {
+    labels := prometheus.Labels{"operation": operation}
+    if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+        s.operationLatency.With(labels).(prometheus.ExemplarObserver).ObserveWithExemplar(
+            seconds, prometheus.Labels{"trace_id": span.TraceID().String()})
+        return
+    }
+    s.operationLatency.With(labels).Observe(seconds)
+}
 
-    return &TagService{
-        repo:             repo,
-        cache:            cache,
-        operationCounter: tagOperationCounter,
-        operationLatency: tagOperationDuration,
-    }, nil
+// replicate hands op to the configured replicator, a no-op when none was
+// installed via WithReplicator. Like publishTagEvent, a replication problem
+// never fails the primary write that already succeeded. Replicate staggers
+// its fan-out across neighbors, so this runs in its own goroutine against a
+// detached context (preserving the trace for correlation but not the
+// request's cancellation) rather than blocking the caller's response on it.
+func (s *TagService) replicate(ctx context.Context, op replication.Op) {
+    if s.replicator == nil {
+        return
+    }
+    detachedCtx := trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+    go s.replicator.Replicate(detachedCtx, op)
+}
+
+// verifyTag checks tag's embedded signature via the configured TagVerifier,
+// a no-op when none was installed via WithTagVerifier.
+func (s *TagService) verifyTag(ctx context.Context, tag *models.Tag) error {
+    if s.verifier == nil {
+        return nil
+    }
+    return s.verifier.VerifyTag(ctx, tag)
 }
 
 // CreateTag creates a new tag with validation and monitoring
 func (s *TagService) CreateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
-    timer := prometheus.NewTimer(s.operationLatency.WithLabelValues("create_tag"))
-    defer timer.ObserveDuration()
+    start := time.Now()
+    ctx, span := s.tracer.Start(ctx, "TagService.CreateTag", trace.WithAttributes(attribute.String("creator_id", tag.CreatorID)))
+    defer span.End()
+    defer func() { s.observeDuration(ctx, "create_tag", time.Since(start).Seconds()) }()
 
     // Validate tag data
     if err := tag.Validate(); err != nil {
+        span.RecordError(err)
         s.operationCounter.WithLabelValues("create_tag", "validation_failed").Inc()
+        s.Logger.WarnContext(ctx, "tag validation failed",
+            "operation", "create_tag", "creator_id", tag.CreatorID, "error", err)
         return nil, status.Errorf(codes.InvalidArgument, "invalid tag data: %v", err)
     }
 
+    if err := s.verifyTag(ctx, tag); err != nil {
+        span.RecordError(err)
+        s.operationCounter.WithLabelValues("create_tag", "invalid_signature").Inc()
+        s.Logger.WarnContext(ctx, "tag signature verification failed",
+            "operation", "create_tag", "creator_id", tag.CreatorID, "error", err)
+        return nil, status.Errorf(codes.PermissionDenied, "invalid tag signature: %v", err)
+    }
+
     // Set default values if not provided
     if tag.ExpiresAt.IsZero() {
         tag.ExpiresAt = time.Now().Add(defaultTagExpirationHours * time.Hour)
@@ -94,7 +333,11 @@ func (s *TagService) CreateTag(ctx context.Context, tag *models.Tag) (*models.Ta
     // Create tag in repository
     createdTag, err := s.repo.CreateTag(ctx, tag)
     if err != nil {
+        span.RecordError(err)
         s.operationCounter.WithLabelValues("create_tag", "failed").Inc()
+        s.Logger.ErrorContext(ctx, "repository create failed",
+            "operation", "create_tag", "creator_id", tag.CreatorID,
+            "latency_ms", time.Since(start).Milliseconds(), "error", err)
         return nil, status.Errorf(codes.Internal, "failed to create tag: %v", err)
     }
 
@@ -103,66 +346,207 @@ func (s *TagService) CreateTag(ctx context.Context, tag *models.Tag) (*models.Ta
     if err := s.cache.Set(ctx, cacheKey, createdTag, defaultCacheTTL*time.Second).Err(); err != nil {
         // Log cache error but don't fail the operation
         s.operationCounter.WithLabelValues("create_tag_cache", "failed").Inc()
+        s.cacheOperations.WithLabelValues("set", "failed").Inc()
+        s.Logger.WarnContext(ctx, "cache set failed",
+            "operation", "create_tag", "tag_id", createdTag.ID.Hex(), "error", err)
+    } else {
+        s.cacheOperations.WithLabelValues("set", "success").Inc()
     }
 
+    s.publishTagEvent(ctx, TagEventCreated, createdTag)
+    s.replicate(ctx, replication.Op{Type: replication.OpCreate, Tag: createdTag})
+
     s.operationCounter.WithLabelValues("create_tag", "success").Inc()
+    s.Logger.InfoContext(ctx, "tag created",
+        "operation", "create_tag", "tag_id", createdTag.ID.Hex(), "creator_id", createdTag.CreatorID,
+        "latency_ms", time.Since(start).Milliseconds())
     return createdTag, nil
 }
 
 // GetNearbyTags retrieves tags near a location with caching
 func (s *TagService) GetNearbyTags(ctx context.Context, location models.Location, radius float64, userStatusLevel string) ([]*models.Tag, error) {
-    timer := prometheus.NewTimer(s.operationLatency.WithLabelValues("get_nearby_tags"))
-    defer timer.ObserveDuration()
+    start := time.Now()
+    ctx, span := s.tracer.Start(ctx, "TagService.GetNearbyTags", trace.WithAttributes(
+        attribute.Float64("geo.radius_meters", radius),
+        attribute.String("user_status_level", userStatusLevel),
+    ))
+    defer span.End()
+    defer func() { s.observeDuration(ctx, "get_nearby_tags", time.Since(start).Seconds()) }()
 
     // Validate parameters
     if err := location.Validate(); err != nil {
+        span.RecordError(err)
         s.operationCounter.WithLabelValues("get_nearby_tags", "validation_failed").Inc()
+        s.Logger.WarnContext(ctx, "location validation failed", "operation", "get_nearby_tags", "error", err)
         return nil, status.Errorf(codes.InvalidArgument, "invalid location: %v", err)
     }
     if radius <= 0 || radius > maxVisibilityRadius {
         s.operationCounter.WithLabelValues("get_nearby_tags", "validation_failed").Inc()
+        s.Logger.WarnContext(ctx, "radius validation failed", "operation", "get_nearby_tags", "radius", radius)
         return nil, status.Errorf(codes.InvalidArgument, "radius must be between 0 and %v meters", maxVisibilityRadius)
     }
 
-    // Try to get from cache first
-    cacheKey := fmt.Sprintf("nearby:%f:%f:%f:%f", location.Latitude, location.Longitude, radius, location.Altitude)
+    // Cache key is quantized to cacheGridPrecision decimal degrees so
+    // nearby callers land on the same key and share both the cache entry
+    // and the singleflight group below, instead of each minting its own.
+    cacheKey := fmt.Sprintf("nearby:%.*f:%.*f:%f:%f",
+        cacheGridPrecision, location.Latitude, cacheGridPrecision, location.Longitude, radius, location.Altitude)
+    negativeCacheKey := "neg:" + cacheKey
+
     var tags []*models.Tag
     if err := s.cache.Get(ctx, cacheKey).Scan(&tags); err == nil {
         s.operationCounter.WithLabelValues("get_nearby_tags_cache", "hit").Inc()
+        s.cacheOperations.WithLabelValues("get", "hit").Inc()
+        tags = s.applyFilters(ctx, tags, &filter.Viewer{StatusLevel: userStatusLevel, Location: location})
+        s.nearbyResultSize.WithLabelValues().Observe(float64(len(tags)))
+        s.Logger.DebugContext(ctx, "cache hit", "operation", "get_nearby_tags", "latency_ms", time.Since(start).Milliseconds())
         return tags, nil
+    } else if err != redis.Nil {
+        s.cacheOperations.WithLabelValues("get", "error").Inc()
+        s.Logger.WarnContext(ctx, "cache get failed", "operation", "get_nearby_tags", "error", err)
+    } else {
+        s.cacheOperations.WithLabelValues("get", "miss").Inc()
+    }
+
+    if err := s.cache.Get(ctx, negativeCacheKey).Err(); err == nil {
+        s.cacheNegativeHits.WithLabelValues().Inc()
+        s.operationCounter.WithLabelValues("get_nearby_tags_cache", "negative_hit").Inc()
+        s.Logger.DebugContext(ctx, "negative cache hit", "operation", "get_nearby_tags", "latency_ms", time.Since(start).Milliseconds())
+        return []*models.Tag{}, nil
+    } else if err != redis.Nil {
+        s.cacheOperations.WithLabelValues("get", "error").Inc()
+        s.Logger.WarnContext(ctx, "negative cache get failed", "operation", "get_nearby_tags", "error", err)
     }
 
-    // Get tags from repository
-    tags, err := s.repo.GetNearbyTags(ctx, location, radius, userStatusLevel)
+    // Get tags from repository, coalescing concurrent callers for the same
+    // cacheKey into a single repository call via singleflight so a burst of
+    // requests for a cold grid cell doesn't stampede Mongo.
+    result, err, shared := s.nearbyGroup.Do(cacheKey, func() (interface{}, error) {
+        fetched, err := s.repo.GetNearbyTags(ctx, location, radius, userStatusLevel)
+        if err != nil {
+            return nil, err
+        }
+
+        if len(fetched) == 0 {
+            if err := s.cache.Set(ctx, negativeCacheKey, "1", negativeCacheTTL*time.Second).Err(); err != nil {
+                s.cacheOperations.WithLabelValues("set", "failed").Inc()
+                s.Logger.WarnContext(ctx, "negative cache set failed", "operation", "get_nearby_tags", "error", err)
+            } else {
+                s.cacheOperations.WithLabelValues("set", "success").Inc()
+            }
+            return fetched, nil
+        }
+
+        if err := s.cache.Set(ctx, cacheKey, fetched, defaultCacheTTL*time.Second).Err(); err != nil {
+            s.operationCounter.WithLabelValues("get_nearby_tags_cache", "failed").Inc()
+            s.cacheOperations.WithLabelValues("set", "failed").Inc()
+            s.Logger.WarnContext(ctx, "cache set failed", "operation", "get_nearby_tags", "error", err)
+        } else {
+            s.cacheOperations.WithLabelValues("set", "success").Inc()
+        }
+        return fetched, nil
+    })
+    if shared {
+        s.cacheSingleflightShared.WithLabelValues().Inc()
+    }
     if err != nil {
+        span.RecordError(err)
         s.operationCounter.WithLabelValues("get_nearby_tags", "failed").Inc()
+        s.Logger.ErrorContext(ctx, "repository query failed",
+            "operation", "get_nearby_tags", "latency_ms", time.Since(start).Milliseconds(), "error", err)
         return nil, status.Errorf(codes.Internal, "failed to get nearby tags: %v", err)
     }
+    tags = result.([]*models.Tag)
+    span.SetAttributes(attribute.Int("result_count", len(tags)))
+    s.nearbyResultSize.WithLabelValues().Observe(float64(len(tags)))
 
-    // Cache the results
-    if err := s.cache.Set(ctx, cacheKey, tags, defaultCacheTTL*time.Second).Err(); err != nil {
-        s.operationCounter.WithLabelValues("get_nearby_tags_cache", "failed").Inc()
-    }
+    tags = s.applyFilters(ctx, tags, &filter.Viewer{StatusLevel: userStatusLevel, Location: location})
 
     s.operationCounter.WithLabelValues("get_nearby_tags", "success").Inc()
+    s.Logger.InfoContext(ctx, "nearby tags retrieved",
+        "operation", "get_nearby_tags", "result_count", len(tags), "latency_ms", time.Since(start).Milliseconds())
     return tags, nil
 }
 
+// applyFilters runs the configured visibility/filter chain over candidates
+// in order, keeping only tags every filter matches, and records how many
+// candidates each filter culled.
+func (s *TagService) applyFilters(ctx context.Context, candidates []*models.Tag, viewer *filter.Viewer) []*models.Tag {
+    for _, f := range s.filters {
+        survivors := candidates[:0:0]
+        for _, tag := range candidates {
+            if f.Match(ctx, tag, viewer) {
+                survivors = append(survivors, tag)
+            }
+        }
+        if culled := len(candidates) - len(survivors); culled > 0 {
+            s.filterCulled.WithLabelValues(f.Name()).Add(float64(culled))
+        }
+        candidates = survivors
+    }
+    return candidates
+}
+
 // UpdateTag updates an existing tag with validation
 func (s *TagService) UpdateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
-    timer := prometheus.NewTimer(s.operationLatency.WithLabelValues("update_tag"))
-    defer timer.ObserveDuration()
+    start := time.Now()
+    ctx, span := s.tracer.Start(ctx, "TagService.UpdateTag", trace.WithAttributes(attribute.String("tag_id", tag.ID.Hex())))
+    defer span.End()
+    defer func() { s.observeDuration(ctx, "update_tag", time.Since(start).Seconds()) }()
 
     // Validate tag data
     if err := tag.Validate(); err != nil {
+        span.RecordError(err)
         s.operationCounter.WithLabelValues("update_tag", "validation_failed").Inc()
+        s.Logger.WarnContext(ctx, "tag validation failed", "operation", "update_tag", "tag_id", tag.ID.Hex(), "error", err)
         return nil, status.Errorf(codes.InvalidArgument, "invalid tag data: %v", err)
     }
 
-    // Update tag in repository
-    updatedTag, err := s.repo.UpdateTag(ctx, tag)
+    if err := s.verifyTag(ctx, tag); err != nil {
+        span.RecordError(err)
+        s.operationCounter.WithLabelValues("update_tag", "invalid_signature").Inc()
+        s.Logger.WarnContext(ctx, "tag signature verification failed",
+            "operation", "update_tag", "tag_id", tag.ID.Hex(), "error", err)
+        return nil, status.Errorf(codes.PermissionDenied, "invalid tag signature: %v", err)
+    }
+
+    // Update tag in repository, consulting the immutable-tag policy (if
+    // configured) against the tag as currently stored before writing. A
+    // GetTagByID failure fails closed - same as RetentionService.skipImmutable -
+    // rather than silently skipping the policy check, since that would let a
+    // transient lookup error through an immutable-tag rule meant to block it.
+    update := policy.UpdateFunc(s.repo.UpdateTag)
+    if s.policyEvaluator != nil {
+        current, err := s.repo.GetTagByID(ctx, tag.ID)
+        if err != nil {
+            span.RecordError(err)
+            s.operationCounter.WithLabelValues("update_tag", "failed").Inc()
+            s.Logger.ErrorContext(ctx, "failed to load current tag for immutable policy check",
+                "operation", "update_tag", "tag_id", tag.ID.Hex(), "error", err)
+            return nil, status.Errorf(codes.Internal, "failed to load tag for immutable policy check: %v", err)
+        }
+        update = policy.PushMiddleware(s.policyEvaluator, current, update)
+    }
+
+    updatedTag, err := update(ctx, tag)
     if err != nil {
+        span.RecordError(err)
+        if errors.Is(err, policy.ErrTagImmutable) {
+            s.operationCounter.WithLabelValues("update_tag", "immutable").Inc()
+            s.Logger.WarnContext(ctx, "update blocked by immutable policy",
+                "operation", "update_tag", "tag_id", tag.ID.Hex(), "error", err)
+            return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+        }
+        if errors.Is(err, ErrTagNotFound) {
+            s.operationCounter.WithLabelValues("update_tag", "not_found").Inc()
+            s.Logger.WarnContext(ctx, "update targeted a tag that no longer exists",
+                "operation", "update_tag", "tag_id", tag.ID.Hex(), "error", err)
+            return nil, ErrTagNotFound
+        }
         s.operationCounter.WithLabelValues("update_tag", "failed").Inc()
+        s.Logger.ErrorContext(ctx, "repository update failed",
+            "operation", "update_tag", "tag_id", tag.ID.Hex(),
+            "latency_ms", time.Since(start).Milliseconds(), "error", err)
         return nil, status.Errorf(codes.Internal, "failed to update tag: %v", err)
     }
 
@@ -170,40 +554,128 @@ func (s *TagService) UpdateTag(ctx context.Context, tag *models.Tag) (*models.Ta
     cacheKey := fmt.Sprintf("tag:%s", updatedTag.ID.Hex())
     if err := s.cache.Set(ctx, cacheKey, updatedTag, defaultCacheTTL*time.Second).Err(); err != nil {
         s.operationCounter.WithLabelValues("update_tag_cache", "failed").Inc()
+        s.Logger.WarnContext(ctx, "cache set failed", "operation", "update_tag", "tag_id", updatedTag.ID.Hex(), "error", err)
     }
 
+    s.publishTagEvent(ctx, TagEventUpdated, updatedTag)
+    s.replicate(ctx, replication.Op{Type: replication.OpUpdate, Tag: updatedTag})
+
     s.operationCounter.WithLabelValues("update_tag", "success").Inc()
+    s.Logger.InfoContext(ctx, "tag updated",
+        "operation", "update_tag", "tag_id", updatedTag.ID.Hex(), "creator_id", updatedTag.CreatorID,
+        "latency_ms", time.Since(start).Milliseconds())
     return updatedTag, nil
 }
 
-// DeleteTag removes a tag and its cache entries
-func (s *TagService) DeleteTag(ctx context.Context, id primitive.ObjectID) error {
-    timer := prometheus.NewTimer(s.operationLatency.WithLabelValues("delete_tag"))
-    defer timer.ObserveDuration()
+// DeleteTag removes a tag and its cache entries. tombstone is required
+// whenever a TagVerifier is configured (see WithTagVerifier) and must carry
+// a fresh signature from the tag's creator key or an admin key; callers may
+// pass nil when no verifier is configured.
+func (s *TagService) DeleteTag(ctx context.Context, id primitive.ObjectID, tombstone *signing.Tombstone) error {
+    start := time.Now()
+    ctx, span := s.tracer.Start(ctx, "TagService.DeleteTag", trace.WithAttributes(attribute.String("tag_id", id.Hex())))
+    defer span.End()
+    defer func() { s.observeDuration(ctx, "delete_tag", time.Since(start).Seconds()) }()
+
+    if s.verifier != nil {
+        if tombstone == nil {
+            s.operationCounter.WithLabelValues("delete_tag", "tombstone_required").Inc()
+            s.Logger.WarnContext(ctx, "delete rejected: signed tombstone required",
+                "operation", "delete_tag", "tag_id", id.Hex())
+            return status.Errorf(codes.InvalidArgument, "a signed tombstone is required to delete tag %s", id.Hex())
+        }
+        current, err := s.repo.GetTagByID(ctx, id)
+        if err != nil {
+            s.operationCounter.WithLabelValues("delete_tag", "failed").Inc()
+            s.Logger.ErrorContext(ctx, "failed to load tag for tombstone verification",
+                "operation", "delete_tag", "tag_id", id.Hex(), "error", err)
+            return status.Errorf(codes.Internal, "failed to load tag: %v", err)
+        }
+        if err := s.verifier.VerifyTombstone(ctx, current, *tombstone); err != nil {
+            span.RecordError(err)
+            s.operationCounter.WithLabelValues("delete_tag", "invalid_signature").Inc()
+            s.Logger.WarnContext(ctx, "tombstone verification failed",
+                "operation", "delete_tag", "tag_id", id.Hex(), "error", err)
+            return status.Errorf(codes.PermissionDenied, "tombstone verification failed: %v", err)
+        }
+    }
+
+    // Fetch the cached tag before deletion purely so we have its location
+    // to address the pub/sub event at; a cache miss just means no event.
+    cacheKey := fmt.Sprintf("tag:%s", id.Hex())
+    var deletedTag models.Tag
+    haveCachedTag := s.cache.Get(ctx, cacheKey).Scan(&deletedTag) == nil
+
+    // Delete from repository, consulting the immutable-tag policy (if
+    // configured) against the tag as currently stored before deleting. A
+    // GetTagByID failure fails closed - same as RetentionService.skipImmutable -
+    // rather than silently skipping the policy check, since that would let a
+    // transient lookup error through an immutable-tag rule meant to block it.
+    deleteFn := policy.DeleteFunc(s.repo.DeleteTag)
+    if s.policyEvaluator != nil {
+        current, err := s.repo.GetTagByID(ctx, id)
+        if err != nil {
+            span.RecordError(err)
+            s.operationCounter.WithLabelValues("delete_tag", "failed").Inc()
+            s.Logger.ErrorContext(ctx, "failed to load current tag for immutable policy check",
+                "operation", "delete_tag", "tag_id", id.Hex(), "error", err)
+            return status.Errorf(codes.Internal, "failed to load tag for immutable policy check: %v", err)
+        }
+        deleteFn = policy.DeleteMiddleware(s.policyEvaluator, current, deleteFn)
+    }
 
-    // Delete from repository
-    if err := s.repo.DeleteTag(ctx, id); err != nil {
+    if err := deleteFn(ctx, id); err != nil {
+        span.RecordError(err)
+        if errors.Is(err, policy.ErrTagImmutable) {
+            s.operationCounter.WithLabelValues("delete_tag", "immutable").Inc()
+            s.Logger.WarnContext(ctx, "delete blocked by immutable policy",
+                "operation", "delete_tag", "tag_id", id.Hex(), "error", err)
+            return status.Errorf(codes.FailedPrecondition, "%v", err)
+        }
+        if errors.Is(err, ErrTagNotFound) {
+            s.operationCounter.WithLabelValues("delete_tag", "not_found").Inc()
+            s.Logger.WarnContext(ctx, "delete targeted a tag that no longer exists",
+                "operation", "delete_tag", "tag_id", id.Hex(), "error", err)
+            return ErrTagNotFound
+        }
         s.operationCounter.WithLabelValues("delete_tag", "failed").Inc()
+        s.Logger.ErrorContext(ctx, "repository delete failed",
+            "operation", "delete_tag", "tag_id", id.Hex(),
+            "latency_ms", time.Since(start).Milliseconds(), "error", err)
         return status.Errorf(codes.Internal, "failed to delete tag: %v", err)
     }
 
     // Remove from cache
-    cacheKey := fmt.Sprintf("tag:%s", id.Hex())
     if err := s.cache.Del(ctx, cacheKey).Err(); err != nil {
         s.operationCounter.WithLabelValues("delete_tag_cache", "failed").Inc()
+        s.Logger.WarnContext(ctx, "cache delete failed", "operation", "delete_tag", "tag_id", id.Hex(), "error", err)
+    }
+
+    if haveCachedTag {
+        s.publishTagEvent(ctx, TagEventDeleted, &deletedTag)
+        s.replicate(ctx, replication.Op{Type: replication.OpDelete, Tag: &deletedTag, TagID: id})
+    } else {
+        s.replicate(ctx, replication.Op{Type: replication.OpDelete, TagID: id})
     }
 
     s.operationCounter.WithLabelValues("delete_tag", "success").Inc()
+    s.Logger.InfoContext(ctx, "tag deleted",
+        "operation", "delete_tag", "tag_id", id.Hex(), "latency_ms", time.Since(start).Milliseconds())
     return nil
 }
 
 // BatchCreateTags creates multiple tags efficiently
 func (s *TagService) BatchCreateTags(ctx context.Context, tags []*models.Tag) ([]*models.Tag, error) {
-    timer := prometheus.NewTimer(s.operationLatency.WithLabelValues("batch_create_tags"))
-    defer timer.ObserveDuration()
+    start := time.Now()
+    ctx, span := s.tracer.Start(ctx, "TagService.BatchCreateTags", trace.WithAttributes(attribute.Int("batch_size", len(tags))))
+    defer span.End()
+    defer func() { s.observeDuration(ctx, "batch_create_tags", time.Since(start).Seconds()) }()
 
     if len(tags) > maxBatchSize {
+        span.RecordError(errors.New("batch size exceeds maximum"))
         s.operationCounter.WithLabelValues("batch_create_tags", "validation_failed").Inc()
+        s.Logger.WarnContext(ctx, "batch size validation failed",
+            "operation", "batch_create_tags", "requested", len(tags), "max", maxBatchSize)
         return nil, status.Errorf(codes.InvalidArgument, "batch size exceeds maximum of %d", maxBatchSize)
     }
 
@@ -211,14 +683,60 @@ func (s *TagService) BatchCreateTags(ctx context.Context, tags []*models.Tag) ([
     for _, tag := range tags {
         if err := tag.Validate(); err != nil {
             s.operationCounter.WithLabelValues("batch_create_tags", "validation_failed").Inc()
+            s.Logger.WarnContext(ctx, "tag validation failed",
+                "operation", "batch_create_tags", "creator_id", tag.CreatorID, "error", err)
             return nil, status.Errorf(codes.InvalidArgument, "invalid tag data: %v", err)
         }
+        if err := s.verifyTag(ctx, tag); err != nil {
+            s.operationCounter.WithLabelValues("batch_create_tags", "invalid_signature").Inc()
+            s.Logger.WarnContext(ctx, "tag signature verification failed",
+                "operation", "batch_create_tags", "creator_id", tag.CreatorID, "error", err)
+            return nil, status.Errorf(codes.PermissionDenied, "invalid tag signature: %v", err)
+        }
+    }
+
+    // Any tag in the batch carrying an existing ID replaces that tag on
+    // write; check each one against the immutable-tag policy before the
+    // batch touches the repository at all, so a single protected tag fails
+    // the whole call rather than partially applying.
+    if s.policyEvaluator != nil {
+        for _, tag := range tags {
+            if tag.ID.IsZero() {
+                continue
+            }
+            current, err := s.repo.GetTagByID(ctx, tag.ID)
+            if err != nil {
+                // Fails closed - same as RetentionService.skipImmutable - rather
+                // than silently treating a lookup error as nothing to protect,
+                // since that would let a transient error through an
+                // immutable-tag rule meant to block this replace.
+                s.operationCounter.WithLabelValues("batch_create_tags", "failed").Inc()
+                s.Logger.ErrorContext(ctx, "failed to load current tag for immutable policy check",
+                    "operation", "batch_create_tags", "tag_id", tag.ID.Hex(), "error", err)
+                return nil, status.Errorf(codes.Internal, "failed to load tag %s for immutable policy check: %v", tag.ID.Hex(), err)
+            }
+            rule, err := s.policyEvaluator.Evaluate(ctx, current)
+            if err != nil {
+                s.operationCounter.WithLabelValues("batch_create_tags", "failed").Inc()
+                return nil, status.Errorf(codes.Internal, "failed to evaluate immutable policy: %v", err)
+            }
+            if rule != nil {
+                s.operationCounter.WithLabelValues("batch_create_tags", "immutable").Inc()
+                s.Logger.WarnContext(ctx, "batch replace blocked by immutable policy",
+                    "operation", "batch_create_tags", "tag_id", tag.ID.Hex(), "rule_id", rule.ID.Hex())
+                return nil, status.Errorf(codes.FailedPrecondition, "%v", fmt.Errorf("%w: blocked by rule %q replacing tag %s", policy.ErrTagImmutable, rule.ID.Hex(), tag.ID.Hex()))
+            }
+        }
     }
 
     // Create tags in repository
     createdTags, err := s.repo.BatchCreateTags(ctx, tags)
     if err != nil {
+        span.RecordError(err)
         s.operationCounter.WithLabelValues("batch_create_tags", "failed").Inc()
+        s.Logger.ErrorContext(ctx, "repository batch create failed",
+            "operation", "batch_create_tags", "batch_size", len(tags),
+            "latency_ms", time.Since(start).Milliseconds(), "error", err)
         return nil, status.Errorf(codes.Internal, "failed to create tags: %v", err)
     }
 
@@ -230,8 +748,28 @@ func (s *TagService) BatchCreateTags(ctx context.Context, tags []*models.Tag) ([
     }
     if _, err := pipe.Exec(ctx); err != nil {
         s.operationCounter.WithLabelValues("batch_create_tags_cache", "failed").Inc()
+        s.Logger.WarnContext(ctx, "cache pipeline failed", "operation", "batch_create_tags", "error", err)
     }
 
+    for _, tag := range createdTags {
+        s.publishTagEvent(ctx, TagEventCreated, tag)
+    }
+    s.replicate(ctx, replication.Op{Type: replication.OpBatchCreate, Tags: createdTags})
+
     s.operationCounter.WithLabelValues("batch_create_tags", "success").Inc()
+    s.Logger.InfoContext(ctx, "batch tags created",
+        "operation", "batch_create_tags", "requested", len(tags), "created", len(createdTags),
+        "latency_ms", time.Since(start).Milliseconds())
     return createdTags, nil
-}
\ No newline at end of file
+}
+// Repository returns the underlying repository, for callers that need to
+// probe it directly. health.Reporter does not use it; it is handed the
+// *repository.MongoRepository via SetDependencies.
+func (s *TagService) Repository() Repository {
+    return s.repo
+}
+
+// PingCache verifies the Redis connection is reachable, for use by health probes.
+func (s *TagService) PingCache(ctx context.Context) error {
+    return s.cache.Ping(ctx).Err()
+}
@@ -0,0 +1,244 @@
+// Package signing implements the tag-signing/verification layer:
+// CreateTag, UpdateTag, and BatchCreateTags require a signature over a
+// canonical serialization of a tag's creator-controlled fields, and
+// DeleteTag requires a freshly signed tombstone from the original
+// creator's key (or an admin key) - modeled on Harbor's requirement that
+// signed artifacts pass a signature check before removal. Key material is
+// resolved through the pluggable KeyRing interface rather than baked into
+// this package, so production can back it with a KMS while tests use an
+// in-memory store.
+package signing
+
+import (
+    "context"
+    "crypto/ed25519"
+    "errors"
+    "fmt"
+    "strconv"
+    "sync"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson/primitive" // v1.11.0
+
+    "../models"
+)
+
+// ErrInvalidSignature is returned whenever a tag or tombstone signature
+// fails to verify, including an unsupported algorithm or a signer key
+// that isn't the one pinned to the tag's creator.
+var ErrInvalidSignature = errors.New("invalid tag signature")
+
+// ErrTombstoneExpired is returned when a delete's signed tombstone is
+// older than TombstoneTTL.
+var ErrTombstoneExpired = errors.New("signed tombstone has expired")
+
+// TombstoneTTL bounds how old a signed delete tombstone may be before
+// Verifier.VerifyTombstone rejects it as stale.
+const TombstoneTTL = 5 * time.Minute
+
+// AlgorithmEd25519 is the only signature algorithm Verifier implements
+// today. models.Tag.SignatureAlg is free text so a future algorithm can be
+// added without a schema change.
+const AlgorithmEd25519 = "ed25519"
+
+// KeyRing resolves the public key material signatures are checked
+// against, with key IDs pinned per creator.
+type KeyRing interface {
+    // PublicKey returns the public key registered under keyID.
+    PublicKey(ctx context.Context, keyID string) (ed25519.PublicKey, error)
+    // KeyIDForCreator returns the key ID pinned to creatorID - the only
+    // key CreateTag/UpdateTag/BatchCreateTags will accept a signature
+    // from for that creator's tags.
+    KeyIDForCreator(ctx context.Context, creatorID string) (string, error)
+    // IsAdminKey reports whether keyID is an admin key, permitted to sign
+    // a delete tombstone for any creator's tag.
+    IsAdminKey(ctx context.Context, keyID string) (bool, error)
+}
+
+// InMemoryKeyRing is a KeyRing backed by plain maps, for tests and local
+// development; production deployments are expected to back KeyRing with a
+// KMS instead.
+type InMemoryKeyRing struct {
+    mu         sync.RWMutex
+    creatorKey map[string]string
+    keys       map[string]ed25519.PublicKey
+    admin      map[string]bool
+}
+
+// NewInMemoryKeyRing builds an empty InMemoryKeyRing; use RegisterCreatorKey
+// and RegisterAdminKey to populate it.
+func NewInMemoryKeyRing() *InMemoryKeyRing {
+    return &InMemoryKeyRing{
+        creatorKey: make(map[string]string),
+        keys:       make(map[string]ed25519.PublicKey),
+        admin:      make(map[string]bool),
+    }
+}
+
+// RegisterCreatorKey pins keyID as creatorID's signing key.
+func (k *InMemoryKeyRing) RegisterCreatorKey(creatorID, keyID string, pub ed25519.PublicKey) {
+    k.mu.Lock()
+    defer k.mu.Unlock()
+    k.creatorKey[creatorID] = keyID
+    k.keys[keyID] = pub
+}
+
+// RegisterAdminKey registers keyID as an admin key, permitted to sign a
+// delete tombstone for any creator's tag.
+func (k *InMemoryKeyRing) RegisterAdminKey(keyID string, pub ed25519.PublicKey) {
+    k.mu.Lock()
+    defer k.mu.Unlock()
+    k.admin[keyID] = true
+    k.keys[keyID] = pub
+}
+
+func (k *InMemoryKeyRing) PublicKey(ctx context.Context, keyID string) (ed25519.PublicKey, error) {
+    k.mu.RLock()
+    defer k.mu.RUnlock()
+    pub, ok := k.keys[keyID]
+    if !ok {
+        return nil, fmt.Errorf("unknown key id %q", keyID)
+    }
+    return pub, nil
+}
+
+func (k *InMemoryKeyRing) KeyIDForCreator(ctx context.Context, creatorID string) (string, error) {
+    k.mu.RLock()
+    defer k.mu.RUnlock()
+    keyID, ok := k.creatorKey[creatorID]
+    if !ok {
+        return "", fmt.Errorf("no key pinned for creator %q", creatorID)
+    }
+    return keyID, nil
+}
+
+func (k *InMemoryKeyRing) IsAdminKey(ctx context.Context, keyID string) (bool, error) {
+    k.mu.RLock()
+    defer k.mu.RUnlock()
+    return k.admin[keyID], nil
+}
+
+// CanonicalTagBytes serializes the creator-controlled fields a tag
+// signature must cover - CreatorID, Location, Content, ExpiresAt,
+// VisibilityRadius, and Visibility - in a fixed field order and delimiter
+// so the same tag content always produces the same bytes to sign.
+func CanonicalTagBytes(tag *models.Tag) []byte {
+    var b []byte
+    b = append(b, tag.CreatorID...)
+    b = append(b, '|')
+    b = appendFloat(b, tag.Location.Latitude)
+    b = append(b, ',')
+    b = appendFloat(b, tag.Location.Longitude)
+    b = append(b, ',')
+    b = appendFloat(b, tag.Location.Altitude)
+    b = append(b, '|')
+    b = append(b, tag.Content...)
+    b = append(b, '|')
+    b = append(b, tag.ExpiresAt.UTC().Format(time.RFC3339Nano)...)
+    b = append(b, '|')
+    b = appendFloat(b, tag.VisibilityRadius)
+    b = append(b, '|')
+    b = append(b, strconv.Itoa(tag.Visibility)...)
+    return b
+}
+
+func appendFloat(b []byte, f float64) []byte {
+    return strconv.AppendFloat(b, f, 'f', -1, 64)
+}
+
+// Tombstone is the signed delete request Verifier.VerifyTombstone checks
+// before TagService.DeleteTag reaches the repository.
+type Tombstone struct {
+    TagID        primitive.ObjectID
+    SignerKeyID  string
+    SignatureAlg string
+    Signature    []byte
+    SignedAt     time.Time
+}
+
+// CanonicalTombstoneBytes serializes the fields a tombstone's signature
+// must cover.
+func CanonicalTombstoneBytes(t Tombstone) []byte {
+    var b []byte
+    b = append(b, t.TagID.Hex()...)
+    b = append(b, '|')
+    b = append(b, t.SignedAt.UTC().Format(time.RFC3339Nano)...)
+    return b
+}
+
+// Verifier checks a tag's embedded signature (on create/update/batch
+// create) or a delete tombstone's signature (on delete) against the
+// creator's key pinned in KeyRing, or, for tombstones, an admin key.
+type Verifier struct {
+    keys KeyRing
+}
+
+// NewVerifier builds a Verifier backed by keys.
+func NewVerifier(keys KeyRing) *Verifier {
+    return &Verifier{keys: keys}
+}
+
+// VerifyTag checks that tag.Signature verifies against CanonicalTagBytes
+// using the key pinned to tag.CreatorID, and that tag.SignerKeyID names
+// that same key.
+func (v *Verifier) VerifyTag(ctx context.Context, tag *models.Tag) error {
+    if tag.SignatureAlg != AlgorithmEd25519 {
+        return fmt.Errorf("%w: unsupported signature algorithm %q", ErrInvalidSignature, tag.SignatureAlg)
+    }
+
+    pinned, err := v.keys.KeyIDForCreator(ctx, tag.CreatorID)
+    if err != nil {
+        return fmt.Errorf("failed to resolve creator key: %w", err)
+    }
+    if tag.SignerKeyID != pinned {
+        return fmt.Errorf("%w: signed by %q, creator's pinned key is %q", ErrInvalidSignature, tag.SignerKeyID, pinned)
+    }
+
+    pub, err := v.keys.PublicKey(ctx, tag.SignerKeyID)
+    if err != nil {
+        return fmt.Errorf("failed to resolve signer key: %w", err)
+    }
+    if !ed25519.Verify(pub, CanonicalTagBytes(tag), tag.Signature) {
+        return ErrInvalidSignature
+    }
+    return nil
+}
+
+// VerifyTombstone checks that tombstone verifies against
+// CanonicalTombstoneBytes, targets current.ID, isn't older than
+// TombstoneTTL, and is signed by current.CreatorID's pinned key or an
+// admin key.
+func (v *Verifier) VerifyTombstone(ctx context.Context, current *models.Tag, tombstone Tombstone) error {
+    if tombstone.TagID != current.ID {
+        return fmt.Errorf("%w: tombstone targets %s, expected %s", ErrInvalidSignature, tombstone.TagID.Hex(), current.ID.Hex())
+    }
+    if time.Since(tombstone.SignedAt) > TombstoneTTL {
+        return ErrTombstoneExpired
+    }
+    if tombstone.SignatureAlg != AlgorithmEd25519 {
+        return fmt.Errorf("%w: unsupported signature algorithm %q", ErrInvalidSignature, tombstone.SignatureAlg)
+    }
+
+    isAdmin, err := v.keys.IsAdminKey(ctx, tombstone.SignerKeyID)
+    if err != nil {
+        return fmt.Errorf("failed to resolve signer key: %w", err)
+    }
+    if !isAdmin {
+        pinned, err := v.keys.KeyIDForCreator(ctx, current.CreatorID)
+        if err != nil {
+            return fmt.Errorf("failed to resolve creator key: %w", err)
+        }
+        if tombstone.SignerKeyID != pinned {
+            return fmt.Errorf("%w: tombstone signed by %q, creator's pinned key is %q", ErrInvalidSignature, tombstone.SignerKeyID, pinned)
+        }
+    }
+
+    pub, err := v.keys.PublicKey(ctx, tombstone.SignerKeyID)
+    if err != nil {
+        return fmt.Errorf("failed to resolve signer key: %w", err)
+    }
+    if !ed25519.Verify(pub, CanonicalTombstoneBytes(tombstone), tombstone.Signature) {
+        return ErrInvalidSignature
+    }
+    return nil
+}
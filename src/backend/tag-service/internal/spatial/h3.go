@@ -0,0 +1,83 @@
+package spatial
+
+import (
+    "math"
+
+    "github.com/uber/h3-go/v4" // v4.1.0
+
+    "../models"
+)
+
+// avgEdgeLengthMeters maps an H3 resolution to its average hexagon edge
+// length in meters (Uber's published H3 resolution table), used to size the
+// kRing cover for a requested query radius at that resolution.
+var avgEdgeLengthMeters = map[int]float64{
+    8:  461.354,
+    9:  174.375,
+    10: 65.907,
+    11: 24.910,
+    12: 9.415,
+}
+
+// H3Index implements Index using Uber's H3 hierarchical hexagonal grid.
+type H3Index struct {
+    resolution int
+}
+
+// NewH3Index constructs an H3-backed spatial index whose Cover operates at
+// resolution, or at CoarseResolution when resolution lies outside
+// [MinResolution, MaxResolution]. resolution
+// typically comes from config.Config's Tag.IndexResolution.
+func NewH3Index(resolution int) *H3Index {
+    if resolution < MinResolution || resolution > MaxResolution {
+        resolution = CoarseResolution
+    }
+    return &H3Index{resolution: resolution}
+}
+
+// Resolution implements Index.
+func (idx *H3Index) Resolution() int {
+    return idx.resolution
+}
+
+// CellIDs implements Index.
+func (idx *H3Index) CellIDs(loc models.Location) (map[int]uint64, error) {
+    latLng := h3.NewLatLng(loc.Latitude, loc.Longitude)
+
+    cells := make(map[int]uint64, MaxResolution-MinResolution+1)
+    for res := MinResolution; res <= MaxResolution; res++ {
+        cell, err := h3.LatLngToCell(latLng, res)
+        if err != nil {
+            return nil, err
+        }
+        cells[res] = uint64(cell)
+    }
+    return cells, nil
+}
+
+// Cover implements Index. It sizes k so that the kRing at idx.resolution
+// fully covers a circle of radiusMeters around loc, then returns the disk.
+func (idx *H3Index) Cover(loc models.Location, radiusMeters float64) ([]uint64, error) {
+    latLng := h3.NewLatLng(loc.Latitude, loc.Longitude)
+
+    center, err := h3.LatLngToCell(latLng, idx.resolution)
+    if err != nil {
+        return nil, err
+    }
+
+    k := int(math.Ceil(radiusMeters / avgEdgeLengthMeters[idx.resolution]))
+    if k < 1 {
+        k = 1
+    }
+
+    disk, err := h3.GridDisk(center, k)
+    if err != nil {
+        return nil, err
+    }
+
+    cellIDs := make([]uint64, len(disk))
+    for i, c := range disk {
+        cellIDs[i] = uint64(c)
+    }
+    return cellIDs, nil
+}
@@ -0,0 +1,37 @@
+// Package spatial provides a pluggable hierarchical spatial index used to
+// narrow down candidate tags before the precise 3D haversine refinement in
+// repository.MongoRepository.GetNearbyTags.
+package spatial
+
+import "../models"
+
+// MinResolution and MaxResolution bound the H3 resolutions the service
+// maintains per tag. Lower resolutions give coarser, cheaper-to-index cells;
+// higher resolutions narrow the candidate set at query time.
+const (
+    MinResolution = 8
+    MaxResolution = 12
+
+    // CoarseResolution is the resolution stored on the indexed field used
+    // for the Mongo $in candidate lookup; it trades precision for a small
+    // index and a short kRing cover list.
+    CoarseResolution = 8
+)
+
+// Index computes and covers hierarchical spatial cells for a location. It is
+// implemented by H3Index; tests may substitute a fake.
+type Index interface {
+    // CellIDs returns the cell ID at every resolution between MinResolution
+    // and MaxResolution for loc, keyed by resolution.
+    CellIDs(loc models.Location) (map[int]uint64, error)
+
+    // Cover returns the set of Resolution() cell IDs that overlap a circle of
+    // radiusMeters centered on loc, i.e. the query cell plus its kRing(k)
+    // neighbours for a k large enough to cover the radius.
+    Cover(loc models.Location, radiusMeters float64) ([]uint64, error)
+
+    // Resolution returns the H3 resolution Cover's cells are drawn at, i.e.
+    // the one callers should use for the indexed field that stores them
+    // (see repository.MongoRepository.h3CellField).
+    Resolution() int
+}
@@ -3,13 +3,18 @@ package integration
 import (
     "context"
     "fmt"
+    "log/slog"
     "math/rand"
+    "os"
     "sync"
+    "sync/atomic"
     "testing"
     "time"
 
+    "github.com/go-redis/redis/v8"
     "github.com/stretchr/testify/require"
     "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/event"
     "go.mongodb.org/mongo-driver/mongo"
     "go.mongodb.org/mongo-driver/mongo/options"
 
@@ -17,6 +22,7 @@ import (
     "../../internal/service"
     "../../internal/config"
     "../../internal/repository"
+    "../../internal/logging"
 )
 
 const (
@@ -30,6 +36,7 @@ var (
     testClient *mongo.Client
     testRepo   *repository.MongoRepository
     testSvc    *service.TagService
+    testLogger = logging.NewTextLogger(os.Stdout, slog.LevelWarn)
 )
 
 // TestMain handles test setup and teardown
@@ -56,7 +63,7 @@ func TestMain(m *testing.M) {
     }
 
     // Initialize repository and service
-    testRepo, err = repository.NewMongoRepository(testClient, cfg)
+    testRepo, err = repository.NewMongoRepository(testClient, cfg, testLogger)
     if err != nil {
         panic(fmt.Sprintf("Failed to create test repository: %v", err))
     }
@@ -66,7 +73,7 @@ func TestMain(m *testing.M) {
 
     // Cleanup test database
     if err := testClient.Database(testDBName).Drop(ctx); err != nil {
-        fmt.Printf("Failed to cleanup test database: %v\n", err)
+        testLogger.Error("failed to cleanup test database", "error", err)
     }
 
     os.Exit(code)
@@ -228,4 +235,118 @@ func BenchmarkNearbyTagQueries(b *testing.B) {
             }
         })
     }
+}
+
+// TestGetNearbyTagsSingleflightCoalescing fires 1000 concurrent GetNearbyTags
+// calls for the same (quantized) location/radius and asserts they coalesce
+// into exactly one repository Aggregate call, with the rest served from the
+// singleflight-shared result or the cache entry it populates.
+func TestGetNearbyTagsSingleflightCoalescing(t *testing.T) {
+    ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+    defer cancel()
+
+    var aggregateCalls int64
+    monitor := &event.CommandMonitor{
+        Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+            if evt.CommandName == "aggregate" {
+                atomic.AddInt64(&aggregateCalls, 1)
+            }
+        },
+    }
+    monitoredClient, err := mongo.Connect(ctx, options.Client().
+        ApplyURI("mongodb://localhost:27017").
+        SetMonitor(monitor))
+    require.NoError(t, err)
+    defer monitoredClient.Disconnect(ctx)
+
+    cfg := &config.Config{
+        Environment: config.EnvDevelopment,
+        Mongo: config.MongoConfig{
+            Database:   testDBName,
+            Collection: testCollectionName,
+            Timeout:    testTimeout,
+        },
+    }
+    monitoredRepo, err := repository.NewMongoRepository(monitoredClient, cfg, testLogger)
+    require.NoError(t, err)
+
+    cache := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+    defer cache.Close()
+
+    svc, err := service.NewTagService(monitoredRepo, cache, testLogger)
+    require.NoError(t, err)
+
+    location := models.Location{
+        Latitude:  40.730610,
+        Longitude: -73.935242,
+        Altitude:  10.0,
+        Geohash:   "dr5r",
+    }
+    tag := &models.Tag{
+        CreatorID:        "singleflight_test_user",
+        Location:         location,
+        Content:          "singleflight coalescing test tag",
+        CreatedAt:        time.Now(),
+        ExpiresAt:        time.Now().Add(24 * time.Hour),
+        VisibilityRadius: 50.0,
+        Visibility:       models.TagVisibilityPublic,
+        Status:           models.TagStatusActive,
+    }
+    _, err = svc.CreateTag(ctx, tag)
+    require.NoError(t, err)
+    // CreateTag's own cache write would otherwise mask the coalescing we're
+    // testing for, so evict it and force GetNearbyTags through the repository.
+    require.NoError(t, cache.FlushDB(ctx).Err())
+
+    const numConcurrentRequests = 1000
+    var wg sync.WaitGroup
+    errChan := make(chan error, numConcurrentRequests)
+    for i := 0; i < numConcurrentRequests; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            if _, err := svc.GetNearbyTags(ctx, location, 50.0, "regular"); err != nil {
+                errChan <- err
+            }
+        }()
+    }
+    wg.Wait()
+    close(errChan)
+
+    for err := range errChan {
+        t.Error(err)
+    }
+
+    require.Equal(t, int64(1), atomic.LoadInt64(&aggregateCalls))
+}
+
+// BenchmarkGeoWithinNearbyQuery compares the H3-covered GetNearbyTags path
+// against the pre-H3 $geoWithin scan on the same 10k-tag grid, at the same
+// radiuses as BenchmarkNearbyTagQueries.
+func BenchmarkGeoWithinNearbyQuery(b *testing.B) {
+    ctx, cancel := context.WithTimeout(context.Background(), benchmarkTimeout)
+    defer cancel()
+
+    baseLocation := models.Location{
+        Latitude:  40.7128,
+        Longitude: -74.0060,
+        Altitude:  10.0,
+        Geohash:   "dr5r",
+    }
+
+    radiuses := []float64{50, 100, 500, 1000}
+    for _, radius := range radiuses {
+        b.Run(fmt.Sprintf("H3_Radius_%vm", radius), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                _, err := testRepo.GetNearbyTags(ctx, baseLocation, radius, "regular")
+                require.NoError(b, err)
+            }
+        })
+        b.Run(fmt.Sprintf("GeoWithin_Radius_%vm", radius), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                _, err := testRepo.GetNearbyTagsGeoWithin(ctx, baseLocation, radius, "regular")
+                require.NoError(b, err)
+            }
+        })
+    }
 }
\ No newline at end of file
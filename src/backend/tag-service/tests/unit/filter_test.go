@@ -0,0 +1,52 @@
+package tests
+
+import (
+    "context"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+
+    "../../internal/filter"
+    "../../internal/models"
+)
+
+func TestStatusTierFilter(t *testing.T) {
+    f := filter.NewStatusTierFilter()
+    ctx := context.Background()
+
+    eliteTag := &models.Tag{Visibility: models.TagVisibilityEliteOnly}
+    publicTag := &models.Tag{Visibility: models.TagVisibilityPublic}
+
+    assert.False(t, f.Match(ctx, eliteTag, &filter.Viewer{StatusLevel: "regular"}))
+    assert.True(t, f.Match(ctx, eliteTag, &filter.Viewer{StatusLevel: "elite"}))
+    assert.True(t, f.Match(ctx, publicTag, &filter.Viewer{StatusLevel: "regular"}))
+}
+
+func TestCombinators(t *testing.T) {
+    ctx := context.Background()
+    tag := &models.Tag{Visibility: models.TagVisibilityEliteOnly, CreatorID: "blocked_user"}
+    viewer := &filter.Viewer{StatusLevel: "regular"}
+
+    blocklist := &filter.CreatorBlocklistFilter{Blocked: map[string]bool{"blocked_user": true}}
+    tier := filter.NewStatusTierFilter()
+
+    t.Run("AllOf fails if any filter fails", func(t *testing.T) {
+        chain := &filter.AllOf{Filters: []filter.TagFilter{tier, blocklist}}
+        assert.False(t, chain.Match(ctx, tag, viewer))
+    })
+
+    t.Run("AnyOf passes if any filter passes", func(t *testing.T) {
+        // tier fails (elite-only tag, regular viewer) but NotFilter{blocklist}
+        // passes - blocklist rejects the blocked creator and NotFilter
+        // inverts that rejection, so AnyOf still matches.
+        chain := &filter.AnyOf{Filters: []filter.TagFilter{tier, &filter.NotFilter{Filter: blocklist}}}
+        assert.True(t, chain.Match(ctx, tag, viewer))
+    })
+
+    t.Run("NotFilter inverts the wrapped result", func(t *testing.T) {
+        // blocklist.Match is false (tag.CreatorID is blocked), so the
+        // inversion is true.
+        not := &filter.NotFilter{Filter: blocklist}
+        assert.True(t, not.Match(ctx, tag, viewer))
+    })
+}
@@ -2,17 +2,25 @@ package tests
 
 import (
     "context"
+    "crypto/ed25519"
+    "errors"
+    "sync"
     "testing"
     "time"
 
+    "github.com/go-redis/redis/v8"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/mock"
     "github.com/stretchr/testify/require"
     "go.mongodb.org/mongo-driver/bson/primitive"
 
     "../../internal/models"
+    "../../internal/policy"
+    "../../internal/replication"
+    "../../internal/retention"
     "../../internal/service"
     "../../internal/repository"
+    "../../internal/signing"
 )
 
 // mockRepository implements repository.Repository interface for testing
@@ -57,29 +65,187 @@ func (m *mockRepository) BatchCreateTags(ctx context.Context, tags []*models.Tag
     return args.Get(0).([]*models.Tag), args.Error(1)
 }
 
+func (m *mockRepository) GetTagByID(ctx context.Context, id primitive.ObjectID) (*models.Tag, error) {
+    args := m.Called(ctx, id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *mockRepository) ActiveImmutableRules(ctx context.Context) ([]*models.ImmutableRule, error) {
+    args := m.Called(ctx)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]*models.ImmutableRule), args.Error(1)
+}
+
+func (m *mockRepository) TagsInScope(ctx context.Context, scope models.RuleScope) ([]*models.Tag, error) {
+    args := m.Called(ctx, scope)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *mockRepository) BatchDeleteTags(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+    args := m.Called(ctx, ids)
+    return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockRepository) RetentionRule(ctx context.Context, id primitive.ObjectID) (*retention.Rule, error) {
+    args := m.Called(ctx, id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*retention.Rule), args.Error(1)
+}
+
+func (m *mockRepository) ActiveRetentionRules(ctx context.Context) ([]*retention.Rule, error) {
+    args := m.Called(ctx)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]*retention.Rule), args.Error(1)
+}
+
+// mockPolicyEvaluator implements policy.ImmutablePolicyEvaluator for
+// tests, so immutable-blocked subtests don't need a real rules collection.
+type mockPolicyEvaluator struct {
+    mock.Mock
+}
+
+func (m *mockPolicyEvaluator) Evaluate(ctx context.Context, tag *models.Tag) (*models.ImmutableRule, error) {
+    args := m.Called(ctx, tag)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*models.ImmutableRule), args.Error(1)
+}
+
+// mockNeighborProvider implements replication.NeighborProvider for tests,
+// mirroring mockRepository's mock.Mock composition.
+type mockNeighborProvider struct {
+    mock.Mock
+}
+
+func (m *mockNeighborProvider) Neighbors(ctx context.Context, tag *models.Tag) ([]replication.Neighbor, error) {
+    args := m.Called(ctx, tag)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]replication.Neighbor), args.Error(1)
+}
+
+// mockNeighborClient implements replication.NeighborClient for tests.
+type mockNeighborClient struct {
+    mock.Mock
+}
+
+func (m *mockNeighborClient) DuplicatePut(ctx context.Context, neighbor replication.Neighbor, op replication.Op) error {
+    args := m.Called(ctx, neighbor, op)
+    return args.Error(0)
+}
+
+// mockOutbox implements replication.Outbox for tests.
+type mockOutbox struct {
+    mock.Mock
+}
+
+func (m *mockOutbox) Enqueue(ctx context.Context, entry replication.OutboxEntry) error {
+    args := m.Called(ctx, entry)
+    return args.Error(0)
+}
+
+// newTestCacheClient returns a Redis client pointed at an address nothing
+// listens on. TagService treats every cache error as non-fatal (logged and
+// ignored), so these unit tests don't need a real Redis server.
+func newTestCacheClient() *redis.Client {
+    return redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+}
+
 // Test setup helper
-func setupTest(t *testing.T) (*mockRepository, service.TagService, context.Context) {
+func setupTest(t *testing.T) (*mockRepository, *service.TagService, context.Context) {
     mockRepo := new(mockRepository)
-    tagService, err := service.NewTagService(mockRepo)
+    tagService, err := service.NewTagService(mockRepo, newTestCacheClient(), nil)
     require.NoError(t, err)
-    
+
     ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
     t.Cleanup(cancel)
-    
+
     return mockRepo, tagService, ctx
 }
 
+// setupTestWithPolicy is setupTest plus an immutable-tag policy evaluator,
+// for the "immutable_blocked" subtests below.
+func setupTestWithPolicy(t *testing.T) (*mockRepository, *mockPolicyEvaluator, *service.TagService, context.Context) {
+    mockRepo := new(mockRepository)
+    mockEval := new(mockPolicyEvaluator)
+    tagService, err := service.NewTagService(mockRepo, newTestCacheClient(), nil, service.WithImmutablePolicyEvaluator(mockEval))
+    require.NoError(t, err)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    t.Cleanup(cancel)
+
+    return mockRepo, mockEval, tagService, ctx
+}
+
+// setupTestWithReplication is setupTest plus a TagReplicator wired to a
+// mockNeighborProvider/mockNeighborClient, for the replication subtests
+// below. stagger is kept at zero so the staggered-ordering assertion
+// doesn't slow the suite down.
+func setupTestWithReplication(t *testing.T) (*mockRepository, *mockNeighborProvider, *mockNeighborClient, *mockOutbox, *service.TagService, context.Context) {
+    mockRepo := new(mockRepository)
+    mockNeighbors := new(mockNeighborProvider)
+    mockClient := new(mockNeighborClient)
+    mockOutboxVal := new(mockOutbox)
+    replicator := replication.NewTagReplicator(mockNeighbors, mockClient, mockOutboxVal, 0, nil)
+    tagService, err := service.NewTagService(mockRepo, newTestCacheClient(), nil, service.WithReplicator(replicator))
+    require.NoError(t, err)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    t.Cleanup(cancel)
+
+    return mockRepo, mockNeighbors, mockClient, mockOutboxVal, tagService, ctx
+}
+
+// setupTestWithVerifier is setupTest plus a signing.Verifier backed by an
+// in-memory key ring, for the signature-verification subtests below. A real
+// Verifier is used rather than a mock since the thing under test is the
+// cryptographic check itself.
+func setupTestWithVerifier(t *testing.T) (*mockRepository, *signing.InMemoryKeyRing, *service.TagService, context.Context) {
+    mockRepo := new(mockRepository)
+    keyRing := signing.NewInMemoryKeyRing()
+    verifier := signing.NewVerifier(keyRing)
+    tagService, err := service.NewTagService(mockRepo, newTestCacheClient(), nil, service.WithTagVerifier(verifier))
+    require.NoError(t, err)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    t.Cleanup(cancel)
+
+    return mockRepo, keyRing, tagService, ctx
+}
+
+// signTag signs tag's canonical bytes with priv under keyID, mutating tag
+// in place the way a client SDK would before submitting it.
+func signTag(priv ed25519.PrivateKey, keyID string, tag *models.Tag) {
+    tag.SignatureAlg = signing.AlgorithmEd25519
+    tag.SignerKeyID = keyID
+    tag.Signature = ed25519.Sign(priv, signing.CanonicalTagBytes(tag))
+}
+
 // Test tag service initialization
 func TestNewTagService(t *testing.T) {
     t.Run("successful initialization", func(t *testing.T) {
         mockRepo := new(mockRepository)
-        svc, err := service.NewTagService(mockRepo)
+        svc, err := service.NewTagService(mockRepo, newTestCacheClient(), nil)
         require.NoError(t, err)
         assert.NotNil(t, svc)
     })
 
     t.Run("nil repository", func(t *testing.T) {
-        svc, err := service.NewTagService(nil)
+        svc, err := service.NewTagService(nil, newTestCacheClient(), nil)
         assert.Error(t, err)
         assert.Nil(t, svc)
     })
@@ -149,6 +315,37 @@ func TestCreateTag(t *testing.T) {
         assert.Error(t, err)
         mockRepo.AssertExpectations(t)
     })
+
+    t.Run("invalid signature", func(t *testing.T) {
+        mockRepo, keyRing, tagService, ctx := setupTestWithVerifier(t)
+
+        pub, priv, err := ed25519.GenerateKey(nil)
+        require.NoError(t, err)
+        keyRing.RegisterCreatorKey("test-user-123", "key-1", pub)
+
+        tag := &models.Tag{
+            CreatorID: "test-user-123",
+            Location: models.Location{
+                Latitude:  40.7128,
+                Longitude: -74.0060,
+                Altitude:  10.0,
+                Geohash:   "dr5r9ydj",
+            },
+            Content:          "Test tag content",
+            VisibilityRadius: 50.0,
+            ExpiresAt:        time.Now().Add(24 * time.Hour),
+            Visibility:       models.TagVisibilityPublic,
+            Status:           models.TagStatusActive,
+        }
+        tag.SignatureAlg = signing.AlgorithmEd25519
+        tag.SignerKeyID = "key-1"
+        tag.Signature = ed25519.Sign(priv, []byte("not-the-canonical-bytes"))
+
+        _, err = tagService.CreateTag(ctx, tag)
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), signing.ErrInvalidSignature.Error())
+        mockRepo.AssertNotCalled(t, "CreateTag")
+    })
 }
 
 // Test nearby tags retrieval
@@ -255,6 +452,101 @@ func TestUpdateTag(t *testing.T) {
         assert.Equal(t, service.ErrTagNotFound, err)
         mockRepo.AssertExpectations(t)
     })
+
+    t.Run("signature mismatch after content edit", func(t *testing.T) {
+        mockRepo, keyRing, tagService, ctx := setupTestWithVerifier(t)
+
+        pub, priv, err := ed25519.GenerateKey(nil)
+        require.NoError(t, err)
+        keyRing.RegisterCreatorKey("test-user-123", "key-1", pub)
+
+        tag := &models.Tag{
+            ID:        primitive.NewObjectID(),
+            CreatorID: "test-user-123",
+            Location: models.Location{
+                Latitude:  40.7128,
+                Longitude: -74.0060,
+                Altitude:  10.0,
+                Geohash:   "dr5r9ydj",
+            },
+            Content:          "Original content",
+            VisibilityRadius: 50.0,
+            ExpiresAt:        time.Now().Add(24 * time.Hour),
+            Visibility:       models.TagVisibilityPublic,
+            Status:           models.TagStatusActive,
+        }
+        signTag(priv, "key-1", tag)
+
+        // Edit the content after signing, without re-signing - the
+        // signature no longer covers what's actually being submitted.
+        tag.Content = "Edited content"
+
+        _, err = tagService.UpdateTag(ctx, tag)
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), signing.ErrInvalidSignature.Error())
+        mockRepo.AssertNotCalled(t, "UpdateTag")
+    })
+
+    t.Run("immutable blocked", func(t *testing.T) {
+        mockRepo, mockEval, tagService, ctx := setupTestWithPolicy(t)
+
+        existing := &models.Tag{
+            ID:        primitive.NewObjectID(),
+            CreatorID: "test-user-123",
+            Content:   "pinned content",
+            Status:    models.TagStatusActive,
+        }
+        tag := &models.Tag{
+            ID:        existing.ID,
+            CreatorID: "test-user-123",
+            Location: models.Location{
+                Latitude:  40.7128,
+                Longitude: -74.0060,
+                Geohash:   "dr5r9ydj",
+            },
+            Content:          "updated content",
+            VisibilityRadius: 50.0,
+            ExpiresAt:        time.Now().Add(24 * time.Hour),
+            Status:           models.TagStatusActive,
+        }
+        rule := &models.ImmutableRule{ID: primitive.NewObjectID(), Enabled: true}
+
+        mockRepo.On("GetTagByID", ctx, existing.ID).Return(existing, nil)
+        mockEval.On("Evaluate", ctx, existing).Return(rule, nil)
+
+        _, err := tagService.UpdateTag(ctx, tag)
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), policy.ErrTagImmutable.Error())
+        mockRepo.AssertNotCalled(t, "UpdateTag")
+        mockRepo.AssertExpectations(t)
+        mockEval.AssertExpectations(t)
+    })
+
+    t.Run("fails closed when the immutable policy lookup errors", func(t *testing.T) {
+        mockRepo, mockEval, tagService, ctx := setupTestWithPolicy(t)
+
+        tag := &models.Tag{
+            ID:        primitive.NewObjectID(),
+            CreatorID: "test-user-123",
+            Location: models.Location{
+                Latitude:  40.7128,
+                Longitude: -74.0060,
+                Geohash:   "dr5r9ydj",
+            },
+            Content:          "updated content",
+            VisibilityRadius: 50.0,
+            ExpiresAt:        time.Now().Add(24 * time.Hour),
+            Status:           models.TagStatusActive,
+        }
+
+        mockRepo.On("GetTagByID", ctx, tag.ID).Return(nil, errors.New("connection reset"))
+
+        _, err := tagService.UpdateTag(ctx, tag)
+        assert.Error(t, err)
+        mockRepo.AssertNotCalled(t, "UpdateTag")
+        mockEval.AssertNotCalled(t, "Evaluate")
+        mockRepo.AssertExpectations(t)
+    })
 }
 
 // Test tag deletion
@@ -265,7 +557,7 @@ func TestDeleteTag(t *testing.T) {
         id := primitive.NewObjectID()
         mockRepo.On("DeleteTag", ctx, id).Return(nil)
 
-        err := tagService.DeleteTag(ctx, id)
+        err := tagService.DeleteTag(ctx, id, nil)
         assert.NoError(t, err)
         mockRepo.AssertExpectations(t)
     })
@@ -274,11 +566,90 @@ func TestDeleteTag(t *testing.T) {
         id := primitive.NewObjectID()
         mockRepo.On("DeleteTag", ctx, id).Return(service.ErrTagNotFound)
 
-        err := tagService.DeleteTag(ctx, id)
+        err := tagService.DeleteTag(ctx, id, nil)
         assert.Error(t, err)
         assert.Equal(t, service.ErrTagNotFound, err)
         mockRepo.AssertExpectations(t)
     })
+
+    t.Run("immutable blocked", func(t *testing.T) {
+        mockRepo, mockEval, tagService, ctx := setupTestWithPolicy(t)
+
+        existing := &models.Tag{
+            ID:        primitive.NewObjectID(),
+            CreatorID: "test-user-123",
+            Content:   "pinned content",
+            Status:    models.TagStatusActive,
+        }
+        rule := &models.ImmutableRule{ID: primitive.NewObjectID(), Enabled: true}
+
+        mockRepo.On("GetTagByID", ctx, existing.ID).Return(existing, nil)
+        mockEval.On("Evaluate", ctx, existing).Return(rule, nil)
+
+        err := tagService.DeleteTag(ctx, existing.ID, nil)
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), policy.ErrTagImmutable.Error())
+        mockRepo.AssertNotCalled(t, "DeleteTag")
+        mockRepo.AssertExpectations(t)
+        mockEval.AssertExpectations(t)
+    })
+
+    t.Run("fails closed when the immutable policy lookup errors", func(t *testing.T) {
+        mockRepo, mockEval, tagService, ctx := setupTestWithPolicy(t)
+
+        id := primitive.NewObjectID()
+        mockRepo.On("GetTagByID", ctx, id).Return(nil, errors.New("connection reset"))
+
+        err := tagService.DeleteTag(ctx, id, nil)
+        assert.Error(t, err)
+        mockRepo.AssertNotCalled(t, "DeleteTag")
+        mockEval.AssertNotCalled(t, "Evaluate")
+        mockRepo.AssertExpectations(t)
+    })
+
+    t.Run("requires signed tombstone", func(t *testing.T) {
+        mockRepo, keyRing, tagService, ctx := setupTestWithVerifier(t)
+
+        pub, priv, err := ed25519.GenerateKey(nil)
+        require.NoError(t, err)
+        keyRing.RegisterCreatorKey("test-user-123", "key-1", pub)
+
+        existing := &models.Tag{
+            ID:        primitive.NewObjectID(),
+            CreatorID: "test-user-123",
+            Content:   "some content",
+            Status:    models.TagStatusActive,
+        }
+
+        t.Run("no tombstone", func(t *testing.T) {
+            err := tagService.DeleteTag(ctx, existing.ID, nil)
+            assert.Error(t, err)
+            mockRepo.AssertNotCalled(t, "DeleteTag")
+        })
+
+        t.Run("signature over the wrong bytes", func(t *testing.T) {
+            mockRepo.On("GetTagByID", ctx, existing.ID).Return(existing, nil).Once()
+
+            tombstone := &signing.Tombstone{TagID: existing.ID, SignerKeyID: "key-1", SignatureAlg: signing.AlgorithmEd25519, SignedAt: time.Now()}
+            tombstone.Signature = ed25519.Sign(priv, []byte("not-the-canonical-bytes"))
+
+            err := tagService.DeleteTag(ctx, existing.ID, tombstone)
+            assert.Error(t, err)
+            mockRepo.AssertNotCalled(t, "DeleteTag")
+        })
+
+        t.Run("valid tombstone succeeds", func(t *testing.T) {
+            mockRepo.On("GetTagByID", ctx, existing.ID).Return(existing, nil).Once()
+            mockRepo.On("DeleteTag", ctx, existing.ID).Return(nil).Once()
+
+            tombstone := &signing.Tombstone{TagID: existing.ID, SignerKeyID: "key-1", SignatureAlg: signing.AlgorithmEd25519, SignedAt: time.Now()}
+            tombstone.Signature = ed25519.Sign(priv, signing.CanonicalTombstoneBytes(*tombstone))
+
+            err := tagService.DeleteTag(ctx, existing.ID, tombstone)
+            assert.NoError(t, err)
+            mockRepo.AssertExpectations(t)
+        })
+    })
 }
 
 // Test batch tag creation
@@ -348,4 +719,294 @@ func TestBatchCreateTags(t *testing.T) {
         assert.Error(t, err)
         mockRepo.AssertNotCalled(t, "BatchCreateTags")
     })
-}
\ No newline at end of file
+
+    t.Run("replaces existing immutable", func(t *testing.T) {
+        mockRepo, mockEval, tagService, ctx := setupTestWithPolicy(t)
+
+        existing := &models.Tag{
+            ID:        primitive.NewObjectID(),
+            CreatorID: "test-user-123",
+            Content:   "pinned content",
+            Status:    models.TagStatusActive,
+        }
+        tags := []*models.Tag{
+            {
+                ID:        existing.ID,
+                CreatorID: "test-user-123",
+                Location: models.Location{
+                    Latitude:  40.7128,
+                    Longitude: -74.0060,
+                    Geohash:   "dr5r9ydj",
+                },
+                Content:          "replacement content",
+                VisibilityRadius: 50.0,
+                ExpiresAt:        time.Now().Add(24 * time.Hour),
+            },
+        }
+        rule := &models.ImmutableRule{ID: primitive.NewObjectID(), Enabled: true}
+
+        mockRepo.On("GetTagByID", ctx, existing.ID).Return(existing, nil)
+        mockEval.On("Evaluate", ctx, existing).Return(rule, nil)
+
+        _, err := tagService.BatchCreateTags(ctx, tags)
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), policy.ErrTagImmutable.Error())
+        mockRepo.AssertNotCalled(t, "BatchCreateTags")
+        mockRepo.AssertExpectations(t)
+        mockEval.AssertExpectations(t)
+    })
+
+    t.Run("fails closed when the immutable policy lookup errors", func(t *testing.T) {
+        mockRepo, mockEval, tagService, ctx := setupTestWithPolicy(t)
+
+        existing := &models.Tag{ID: primitive.NewObjectID()}
+        tags := []*models.Tag{
+            {
+                ID:        existing.ID,
+                CreatorID: "test-user-123",
+                Location: models.Location{
+                    Latitude:  40.7128,
+                    Longitude: -74.0060,
+                    Geohash:   "dr5r9ydj",
+                },
+                Content:          "replacement content",
+                VisibilityRadius: 50.0,
+                ExpiresAt:        time.Now().Add(24 * time.Hour),
+            },
+        }
+
+        mockRepo.On("GetTagByID", ctx, existing.ID).Return(nil, errors.New("connection reset"))
+
+        _, err := tagService.BatchCreateTags(ctx, tags)
+        assert.Error(t, err)
+        mockRepo.AssertNotCalled(t, "BatchCreateTags")
+        mockEval.AssertNotCalled(t, "Evaluate")
+        mockRepo.AssertExpectations(t)
+    })
+
+    t.Run("one bad signature fails batch", func(t *testing.T) {
+        mockRepo, keyRing, tagService, ctx := setupTestWithVerifier(t)
+
+        pub, priv, err := ed25519.GenerateKey(nil)
+        require.NoError(t, err)
+        keyRing.RegisterCreatorKey("test-user-123", "key-1", pub)
+
+        goodTag := &models.Tag{
+            CreatorID: "test-user-123",
+            Location: models.Location{
+                Latitude:  40.7128,
+                Longitude: -74.0060,
+                Altitude:  10.0,
+                Geohash:   "dr5r9ydj",
+            },
+            Content:          "Tag 1",
+            VisibilityRadius: 50.0,
+            ExpiresAt:        time.Now().Add(24 * time.Hour),
+        }
+        signTag(priv, "key-1", goodTag)
+
+        badTag := &models.Tag{
+            CreatorID: "test-user-123",
+            Location: models.Location{
+                Latitude:  40.7129,
+                Longitude: -74.0061,
+                Altitude:  10.0,
+                Geohash:   "dr5r9ydj",
+            },
+            Content:          "Tag 2",
+            VisibilityRadius: 50.0,
+            ExpiresAt:        time.Now().Add(24 * time.Hour),
+        }
+        badTag.SignatureAlg = signing.AlgorithmEd25519
+        badTag.SignerKeyID = "key-1"
+        badTag.Signature = ed25519.Sign(priv, []byte("not-the-canonical-bytes"))
+
+        _, err = tagService.BatchCreateTags(ctx, []*models.Tag{goodTag, badTag})
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), signing.ErrInvalidSignature.Error())
+        mockRepo.AssertNotCalled(t, "BatchCreateTags")
+    })
+}
+
+// Test peer-replication fan-out on tag creation
+func TestCreateTagReplication(t *testing.T) {
+    newTag := func() *models.Tag {
+        return &models.Tag{
+            CreatorID: "test-user-123",
+            Location: models.Location{
+                Latitude:  40.7128,
+                Longitude: -74.0060,
+                Altitude:  10.0,
+                Geohash:   "dr5r9ydj",
+            },
+            Content:           "Test tag content",
+            VisibilityRadius:  50.0,
+            ExpiresAt:         time.Now().Add(24 * time.Hour),
+            Visibility:        models.TagVisibilityPublic,
+            Status:            models.TagStatusActive,
+        }
+    }
+
+    t.Run("duplicates to every neighbor in order", func(t *testing.T) {
+        mockRepo, mockNeighbors, mockClient, _, tagService, ctx := setupTestWithReplication(t)
+
+        tag := newTag()
+        expectedTag := *tag
+        expectedTag.ID = primitive.NewObjectID()
+        mockRepo.On("CreateTag", ctx, tag).Return(&expectedTag, nil)
+
+        neighbors := []replication.Neighbor{{ID: "n1", Addr: "http://n1"}, {ID: "n2", Addr: "http://n2"}}
+        mockNeighbors.On("Neighbors", mock.Anything, &expectedTag).Return(neighbors, nil)
+
+        var mu sync.Mutex
+        var called []string
+        mockClient.On("DuplicatePut", mock.Anything, neighbors[0], mock.Anything).
+            Run(func(args mock.Arguments) { mu.Lock(); called = append(called, "n1"); mu.Unlock() }).Return(nil)
+        mockClient.On("DuplicatePut", mock.Anything, neighbors[1], mock.Anything).
+            Run(func(args mock.Arguments) { mu.Lock(); called = append(called, "n2"); mu.Unlock() }).Return(nil)
+
+        createdTag, err := tagService.CreateTag(ctx, tag)
+        require.NoError(t, err)
+        assert.Equal(t, expectedTag.ID, createdTag.ID)
+
+        // Replication now fans out on its own goroutine rather than blocking
+        // CreateTag's response, so give it a moment to finish before asserting.
+        require.Eventually(t, func() bool {
+            mu.Lock()
+            defer mu.Unlock()
+            return len(called) == 2
+        }, time.Second, 10*time.Millisecond)
+        assert.Equal(t, []string{"n1", "n2"}, called)
+        mockRepo.AssertExpectations(t)
+        mockNeighbors.AssertExpectations(t)
+        mockClient.AssertExpectations(t)
+    })
+
+    t.Run("primary write still succeeds when a neighbor errors", func(t *testing.T) {
+        mockRepo, mockNeighbors, mockClient, mockOutboxVal, tagService, ctx := setupTestWithReplication(t)
+
+        tag := newTag()
+        expectedTag := *tag
+        expectedTag.ID = primitive.NewObjectID()
+        mockRepo.On("CreateTag", ctx, tag).Return(&expectedTag, nil)
+
+        neighbors := []replication.Neighbor{{ID: "n1", Addr: "http://n1"}}
+        mockNeighbors.On("Neighbors", mock.Anything, &expectedTag).Return(neighbors, nil)
+        mockClient.On("DuplicatePut", mock.Anything, neighbors[0], mock.Anything).
+            Return(assert.AnError)
+
+        var mu sync.Mutex
+        var enqueued bool
+        mockOutboxVal.On("Enqueue", mock.Anything, mock.Anything).
+            Run(func(args mock.Arguments) { mu.Lock(); enqueued = true; mu.Unlock() }).Return(nil)
+
+        createdTag, err := tagService.CreateTag(ctx, tag)
+        require.NoError(t, err)
+        assert.Equal(t, expectedTag.ID, createdTag.ID)
+
+        // Replication now fans out on its own goroutine; wait for the
+        // outbox enqueue the failed neighbor triggers before asserting on it.
+        require.Eventually(t, func() bool {
+            mu.Lock()
+            defer mu.Unlock()
+            return enqueued
+        }, time.Second, 10*time.Millisecond)
+        mockRepo.AssertExpectations(t)
+        mockNeighbors.AssertExpectations(t)
+        mockClient.AssertExpectations(t)
+        mockOutboxVal.AssertExpectations(t)
+    })
+}
+
+// Test retention sweep behavior: candidate selection via DryRun, the
+// immutable-tag guard, and bounded-chunk deletes.
+func TestRetentionSweep(t *testing.T) {
+    t.Run("dry_run", func(t *testing.T) {
+        mockRepo := new(mockRepository)
+        retentionService, err := service.NewRetentionService(mockRepo, mockRepo)
+        require.NoError(t, err)
+        ctx := context.Background()
+
+        rule := &retention.Rule{
+            ID:       primitive.NewObjectID(),
+            Name:     "expire-old-tags",
+            Strategy: retention.StrategyMaxAge,
+            MaxAge:   24 * time.Hour,
+        }
+        oldTag := &models.Tag{ID: primitive.NewObjectID(), ExpiresAt: time.Now().Add(-48 * time.Hour)}
+        freshTag := &models.Tag{ID: primitive.NewObjectID(), ExpiresAt: time.Now().Add(time.Hour)}
+
+        mockRepo.On("RetentionRule", ctx, rule.ID).Return(rule, nil)
+        mockRepo.On("TagsInScope", ctx, rule.Scope).Return([]*models.Tag{oldTag, freshTag}, nil)
+
+        candidates, err := retentionService.DryRun(ctx, rule.ID)
+        require.NoError(t, err)
+        require.Len(t, candidates, 1)
+        assert.Equal(t, oldTag.ID, candidates[0].ID)
+        mockRepo.AssertNotCalled(t, "BatchDeleteTags")
+        mockRepo.AssertExpectations(t)
+    })
+
+    t.Run("skips_immutable", func(t *testing.T) {
+        mockRepo := new(mockRepository)
+        mockEval := new(mockPolicyEvaluator)
+        retentionService, err := service.NewRetentionService(mockRepo, mockRepo, service.WithRetentionPolicyEvaluator(mockEval))
+        require.NoError(t, err)
+        ctx := context.Background()
+
+        rule := &retention.Rule{
+            ID:       primitive.NewObjectID(),
+            Name:     "expire-old-tags",
+            Strategy: retention.StrategyMaxAge,
+            MaxAge:   24 * time.Hour,
+            Enabled:  true,
+        }
+        pinnedTag := &models.Tag{ID: primitive.NewObjectID(), ExpiresAt: time.Now().Add(-48 * time.Hour)}
+        deletableTag := &models.Tag{ID: primitive.NewObjectID(), ExpiresAt: time.Now().Add(-48 * time.Hour)}
+        pinningRule := &models.ImmutableRule{ID: primitive.NewObjectID(), Enabled: true}
+
+        mockRepo.On("ActiveRetentionRules", ctx).Return([]*retention.Rule{rule}, nil)
+        mockRepo.On("TagsInScope", ctx, rule.Scope).Return([]*models.Tag{pinnedTag, deletableTag}, nil)
+        mockEval.On("Evaluate", ctx, pinnedTag).Return(pinningRule, nil)
+        mockEval.On("Evaluate", ctx, deletableTag).Return(nil, nil)
+        mockRepo.On("BatchDeleteTags", ctx, []primitive.ObjectID{deletableTag.ID}).Return(int64(1), nil)
+
+        err = retentionService.Sweep(ctx)
+        require.NoError(t, err)
+        mockRepo.AssertExpectations(t)
+        mockEval.AssertExpectations(t)
+    })
+
+    t.Run("batch_delete_chunked", func(t *testing.T) {
+        mockRepo := new(mockRepository)
+        retentionService, err := service.NewRetentionService(mockRepo, mockRepo)
+        require.NoError(t, err)
+        ctx := context.Background()
+
+        rule := &retention.Rule{
+            ID:       primitive.NewObjectID(),
+            Name:     "expire-old-tags",
+            Strategy: retention.StrategyMaxAge,
+            MaxAge:   24 * time.Hour,
+            Enabled:  true,
+        }
+
+        const candidateCount = 501 // one chunk over the 500-tag chunk size
+        tags := make([]*models.Tag, candidateCount)
+        for i := range tags {
+            tags[i] = &models.Tag{ID: primitive.NewObjectID(), ExpiresAt: time.Now().Add(-48 * time.Hour)}
+        }
+
+        mockRepo.On("ActiveRetentionRules", ctx).Return([]*retention.Rule{rule}, nil)
+        mockRepo.On("TagsInScope", ctx, rule.Scope).Return(tags, nil)
+        mockRepo.On("BatchDeleteTags", ctx, mock.MatchedBy(func(ids []primitive.ObjectID) bool { return len(ids) == 500 })).
+            Return(int64(500), nil).Once()
+        mockRepo.On("BatchDeleteTags", ctx, mock.MatchedBy(func(ids []primitive.ObjectID) bool { return len(ids) == 1 })).
+            Return(int64(1), nil).Once()
+
+        err = retentionService.Sweep(ctx)
+        require.NoError(t, err)
+        mockRepo.AssertExpectations(t)
+        mockRepo.AssertNumberOfCalls(t, "BatchDeleteTags", 2)
+    })
+}